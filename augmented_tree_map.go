@@ -0,0 +1,186 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// ===================================增强有序映射===================================
+
+// 在每个节点中保存子树聚合值的有序映射，聚合值由幺半群定义：
+// lift将键值对转换为聚合值，combine为满足结合律的合并函数，identity为单位元
+// 支持O(logn)的范围聚合查询和按前缀聚合值的二分查找
+type AugmentedTreeMap[K comparable, V, A any] struct {
+	m        TreeMap[K, augmentedValue[V, A]]
+	identity A
+	lift     func(K, V) A
+	combine  func(A, A) A
+}
+
+type augmentedValue[V, A any] struct {
+	v   V
+	agg A // 子树中所有键值对按键升序的聚合值
+}
+
+// 创建按自然顺序排序的增强映射
+func NewAugmentedTreeMap[K cmp.Ordered, V, A any](identity A, lift func(K, V) A, combine func(A, A) A) AugmentedTreeMap[K, V, A] {
+	return NewAugmentedTreeMapFunc(cmp.Compare[K], identity, lift, combine)
+}
+
+// 创建使用自定义比较函数排序的增强映射
+func NewAugmentedTreeMapFunc[K comparable, V, A any](cmp func(K, K) int, identity A,
+	lift func(K, V) A, combine func(A, A) A) AugmentedTreeMap[K, V, A] {
+	o := AugmentedTreeMap[K, V, A]{m: NewTreeMapFunc[K, augmentedValue[V, A]](cmp),
+		identity: identity, lift: lift, combine: combine}
+	o.m.w = func(node *avlNode[K, augmentedValue[V, A]]) {
+		node.v.agg = o.combine(o.combine(o.agg(node.left), o.lift(node.k, node.v.v)), o.agg(node.right))
+	}
+	return o
+}
+
+// 辅助函数，返回子树的聚合值
+func (o AugmentedTreeMap[K, V, A]) agg(node *avlNode[K, augmentedValue[V, A]]) A {
+	if node == nil {
+		return o.identity
+	}
+	return node.v.agg
+}
+
+// --------------------Container接口--------------------
+func (o AugmentedTreeMap[K, V, A]) Len() int { return o.m.Len() }
+func (o AugmentedTreeMap[K, V, A]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "AugmentedTreeMap[" + strings.Join(entries, " ") + "]"
+}
+func (o AugmentedTreeMap[K, V, A]) Clear() AugmentedTreeMap[K, V, A] { o.m.Clear(); return o }
+func (o AugmentedTreeMap[K, V, A]) Clone() AugmentedTreeMap[K, V, A] { // 节点中的聚合值会一起复制，代价为O(n)
+	res := NewAugmentedTreeMapFunc(o.m.cmp, o.identity, o.lift, o.combine)
+	res.m.setRoot(o.m.root().deepCopy())
+	return res
+}
+
+// --------------------MapContainer接口--------------------
+func (o AugmentedTreeMap[K, V, A]) ForEach(f func(k K, v V)) AugmentedTreeMap[K, V, A] {
+	for k, v := range o.All() {
+		f(k, v)
+	}
+	return o
+}
+func (o AugmentedTreeMap[K, V, A]) ToMap() map[K]V {
+	res := make(map[K]V, o.Len())
+	for k, v := range o.All() {
+		res[k] = v
+	}
+	return res
+}
+
+// 按键升序和降序惰性遍历
+func (o AugmentedTreeMap[K, V, A]) All() iter.Seq2[K, V] { return augmentedEntries(o.m.All()) }
+func (o AugmentedTreeMap[K, V, A]) Backward() iter.Seq2[K, V] {
+	return augmentedEntries(o.m.Backward())
+}
+func (o AugmentedTreeMap[K, V, A]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o AugmentedTreeMap[K, V, A]) Values() iter.Seq[V] { return seqValues(o.All()) }
+func (o AugmentedTreeMap[K, V, A]) KeySlice() []K       { return slices.Collect(o.Keys()) }
+func (o AugmentedTreeMap[K, V, A]) ValueSlice() []V     { return slices.Collect(o.Values()) }
+
+// 去掉键值对中的聚合值
+func augmentedEntries[K, V, A any](seq iter.Seq2[K, augmentedValue[V, A]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if !yield(k, v.v) {
+				return
+			}
+		}
+	}
+}
+
+// 基本操作
+func (o AugmentedTreeMap[K, V, A]) Has(k K) bool { return o.m.Has(k) }
+func (o AugmentedTreeMap[K, V, A]) Get(k K) V    { return o.m.Get(k).v } // 不存在时返回零值
+func (o AugmentedTreeMap[K, V, A]) GetOr(k K, v V) V {
+	if res, ok := o.Lookup(k); ok {
+		return res
+	}
+	return v
+}
+func (o AugmentedTreeMap[K, V, A]) Lookup(k K) (V, bool) {
+	v, ok := o.m.Lookup(k)
+	return v.v, ok
+}
+func (o AugmentedTreeMap[K, V, A]) Set(k K, v V) AugmentedTreeMap[K, V, A] {
+	o.m.Set(k, augmentedValue[V, A]{v: v})
+	return o
+}
+func (o AugmentedTreeMap[K, V, A]) Del(k K) AugmentedTreeMap[K, V, A] { o.m.Del(k); return o }
+
+// --------------------聚合查询--------------------
+
+// 所有键值对的聚合值，O(1)
+func (o AugmentedTreeMap[K, V, A]) AggregateAll() A { return o.agg(o.m.root()) }
+
+// lo到hi之间的键值对的聚合值，由loInclusive和hiInclusive决定是否包含边界，O(logn)
+func (o AugmentedTreeMap[K, V, A]) Aggregate(lo, hi K, loInclusive, hiInclusive bool) A {
+	aboveLo := func(k K) bool { c := o.m.cmp(k, lo); return c > 0 || loInclusive && c == 0 }
+	belowHi := func(k K) bool { c := o.m.cmp(k, hi); return c < 0 || hiInclusive && c == 0 }
+
+	// 找到第一个在范围内的节点，范围内的其他节点都在它的子树中
+	node := o.m.root()
+	for node != nil && !(aboveLo(node.k) && belowHi(node.k)) {
+		if aboveLo(node.k) {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	if node == nil {
+		return o.identity
+	}
+
+	// 左子树中满足下界的后缀，右子树中满足上界的前缀
+	suffix := o.identity
+	for p := node.left; p != nil; {
+		if aboveLo(p.k) {
+			suffix = o.combine(o.combine(o.lift(p.k, p.v.v), o.agg(p.right)), suffix)
+			p = p.left
+		} else {
+			p = p.right
+		}
+	}
+	prefix := o.identity
+	for p := node.right; p != nil; {
+		if belowHi(p.k) {
+			prefix = o.combine(prefix, o.combine(o.agg(p.left), o.lift(p.k, p.v.v)))
+			p = p.right
+		} else {
+			p = p.left
+		}
+	}
+	return o.combine(o.combine(suffix, o.lift(node.k, node.v.v)), prefix)
+}
+
+// 最小的键k，使得所有小于等于k的键值对的聚合值满足f，不存在时返回false
+// 要求f具有单调性，即前缀扩大后f的结果只会从false变为true，O(logn)
+// 例如在前缀和中查找第一个使前缀和大于等于x的键
+func (o AugmentedTreeMap[K, V, A]) SearchPrefix(f func(A) bool) (K, bool) {
+	acc := o.identity
+	if f(acc) { // 所有前缀都满足f
+		return o.m.First().key()
+	}
+	for node := o.m.root(); node != nil; {
+		if left := o.combine(acc, o.agg(node.left)); f(left) {
+			node = node.left
+		} else if cur := o.combine(left, o.lift(node.k, node.v.v)); f(cur) {
+			return node.k, true
+		} else {
+			acc, node = cur, node.right
+		}
+	}
+	return *new(K), false
+}
@@ -0,0 +1,80 @@
+package gods
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test增强有序映射(t *testing.T) {
+	// 区间求和
+	sum := NewAugmentedTreeMap(0, func(k, v int) int { return v }, func(a, b int) int { return a + b })
+	for i := range 10 {
+		sum.Set(i*10, i)
+	}
+	fmt.Println(sum, "总和：", sum.AggregateAll(), "[20,50)的和：", sum.Aggregate(20, 50, true, false))
+	if sum.AggregateAll() != 45 || sum.Aggregate(20, 50, true, false) != 9 || sum.Aggregate(15, 55, false, true) != 14 {
+		t.Errorf("区间求和错误")
+	}
+	// 前缀和大于等于10的最小键：0+1+2+3+4=10
+	if k, ok := sum.SearchPrefix(func(s int) bool { return s >= 10 }); k != 40 || !ok {
+		t.Errorf("SearchPrefix错误：%v", k)
+	}
+	if _, ok := sum.SearchPrefix(func(s int) bool { return s > 45 }); ok {
+		t.Errorf("不存在时SearchPrefix应返回false")
+	}
+	if k, ok := sum.SearchPrefix(func(s int) bool { return true }); k != 0 || !ok {
+		t.Errorf("f恒为true时SearchPrefix应返回第一个键")
+	}
+
+	// 非交换的聚合：按键顺序拼接
+	concat := NewAugmentedTreeMap("", func(k int, v string) string { return v }, func(a, b string) string { return a + b })
+	for _, k := range rand.Perm(26) {
+		concat.Set(k, string(rune('a'+k)))
+	}
+	concat.Del(3).Set(4, "E")
+	if concat.AggregateAll() != "abcEfghijklmnopqrstuvwxyz" || concat.Aggregate(1, 6, true, false) != "bcEf" {
+		t.Errorf("拼接错误：%v", concat.AggregateAll())
+	}
+
+	// 最大值，与暴力结果比较
+	mx := NewAugmentedTreeMap(math.MinInt, func(k, v int) int { return v }, func(a, b int) int { return max(a, b) })
+	m := map[int]int{}
+	for range 2000 {
+		k := rand.Intn(100)
+		if rand.Intn(3) == 0 {
+			mx.Del(k)
+			delete(m, k)
+		} else {
+			v := rand.Intn(1000)
+			mx.Set(k, v)
+			m[k] = v
+		}
+		lo, hi := rand.Intn(100), rand.Intn(100)
+		loInc, hiInc := rand.Intn(2) == 0, rand.Intn(2) == 0
+		want := math.MinInt
+		for k, v := range m {
+			if (k > lo || loInc && k == lo) && (k < hi || hiInc && k == hi) {
+				want = max(want, v)
+			}
+		}
+		if got := mx.Aggregate(lo, hi, loInc, hiInc); got != want {
+			t.Fatalf("Aggregate(%d, %d, %v, %v)=%d，期望%d", lo, hi, loInc, hiInc, got, want)
+		}
+	}
+	clone := mx.Clone()
+	mx.Clear()
+	if mx.AggregateAll() != math.MinInt || clone.Len() != len(m) || !slices.Equal(clone.KeySlice(), slices.Sorted(func(yield func(int) bool) {
+		for k := range m {
+			yield(k)
+		}
+	})) {
+		t.Errorf("Clone或Clear错误")
+	}
+	clone.Set(1000, 1<<30)
+	if clone.AggregateAll() != 1<<30 {
+		t.Errorf("Clone后修改错误")
+	}
+}
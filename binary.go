@@ -0,0 +1,448 @@
+package gods
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ===================================二进制快照===================================
+
+// 所有容器实现encoding.BinaryMarshaler和encoding.BinaryUnmarshaler，gob会自动使用这两个方法
+// 快照格式：4字节魔数"gods" + 1字节版本号 + 1字节容器类型，之后是gob编码的数据
+// 解码时支持零值容器，非零值容器会先清空，并保留比较函数、工厂函数等设置
+
+const binaryVersion = 1
+
+var binaryMagic = []byte("gods")
+
+// 快照中的容器类型，只能在末尾追加
+const (
+	binaryArray byte = iota + 1
+	binaryList
+	binaryStack
+	binaryQueue
+	binaryHeap
+	binaryHashSet
+	binaryLinkedHashSet
+	binaryTreeSet
+	binaryMultiHashSet
+	binaryMultiTreeSet
+	binaryHashMap
+	binaryLinkedHashMap
+	binaryTreeMap
+	binaryOptional
+	binaryCustomHashSet
+	binaryCustomHashMap
+)
+
+// --------------------快照数据--------------------
+
+type valuesSnapshot[T any] struct {
+	Values []T
+}
+type countsSnapshot[T any] struct {
+	Values []T
+	Counts []int
+}
+type entriesSnapshot[K, V any] struct {
+	Keys        []K
+	Values      []V
+	AccessOrder bool // 仅用于LinkedHashMap
+	MaxCap      int  // 仅用于LinkedHashMap
+}
+
+// --------------------辅助函数--------------------
+
+func marshalSnapshot(kind byte, snapshot any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.Write(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	buf.WriteByte(kind)
+	if err := gob.NewEncoder(buf).Encode(snapshot); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 一次遍历同时收集键和值，保证两者的顺序一致
+func unzipEntries[K, V any](entries iter.Seq2[K, V]) (keys []K, values []V) {
+	for k, v := range entries {
+		keys, values = append(keys, k), append(values, v)
+	}
+	return keys, values
+}
+
+func unmarshalSnapshot(data []byte, kind byte, snapshot any) error {
+	n := len(binaryMagic)
+	if len(data) < n+2 || !bytes.Equal(data[:n], binaryMagic) {
+		return fmt.Errorf("gods: 不是有效的容器快照")
+	}
+	if version := data[n]; version > binaryVersion {
+		return fmt.Errorf("gods: 不支持的快照版本%d", version)
+	}
+	if data[n+1] != kind {
+		return fmt.Errorf("gods: 快照的容器类型不匹配")
+	}
+	return gob.NewDecoder(bytes.NewReader(data[n+2:])).Decode(snapshot)
+}
+
+// --------------------Optional--------------------
+
+func (o Optional[T]) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(binaryOptional, valuesSnapshot[T]{Values: o.toSlice()})
+}
+func (o *Optional[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryOptional, &s); err != nil {
+		return err
+	}
+	o.v = nil
+	if len(s.Values) > 0 {
+		o.v = &s.Values[0]
+	}
+	return nil
+}
+func (o Optional[T]) toSlice() []T {
+	if !o.Exists() {
+		return nil
+	}
+	return []T{*o.v}
+}
+
+// --------------------Array和List--------------------
+
+func (o Array[T]) MarshalBinary() ([]byte, error) {
+	if o.data == nil {
+		return marshalSnapshot(binaryArray, valuesSnapshot[T]{})
+	}
+	return marshalSnapshot(binaryArray, valuesSnapshot[T]{Values: *o.data})
+}
+func (o *Array[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryArray, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewArray[T]()
+	}
+	*o.data = s.Values
+	return nil
+}
+
+func (o List[T]) MarshalBinary() ([]byte, error) {
+	if o.root == nil {
+		return marshalSnapshot(binaryList, valuesSnapshot[T]{})
+	}
+	return marshalSnapshot(binaryList, valuesSnapshot[T]{Values: o.ToSlice()})
+}
+func (o *List[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryList, &s); err != nil {
+		return err
+	}
+	if o.root == nil {
+		*o = NewList[T]()
+	}
+	o.Clear()
+	for _, v := range s.Values {
+		o.PushBack(v)
+	}
+	return nil
+}
+
+// --------------------栈、队列和堆--------------------
+
+func (o Stack[T]) MarshalBinary() ([]byte, error) {
+	if o.data == nil {
+		return marshalSnapshot(binaryStack, valuesSnapshot[T]{})
+	}
+	return marshalSnapshot(binaryStack, valuesSnapshot[T]{Values: *o.data})
+}
+func (o *Stack[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryStack, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewStack[T]()
+	}
+	*o.data = s.Values
+	return nil
+}
+
+func (o Queue[T]) MarshalBinary() ([]byte, error) {
+	if o.d == nil {
+		return marshalSnapshot(binaryQueue, valuesSnapshot[T]{})
+	}
+	return marshalSnapshot(binaryQueue, valuesSnapshot[T]{Values: o.ToSlice()})
+}
+func (o *Queue[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryQueue, &s); err != nil {
+		return err
+	}
+	if o.d == nil {
+		*o = NewQueue[T]()
+	}
+	o.Clear()
+	for _, v := range s.Values {
+		o.PushBack(v)
+	}
+	return nil
+}
+
+// 堆直接保存内部数组，解码时O(n)建堆
+func (o Heap[T]) MarshalBinary() ([]byte, error) {
+	if o.data == nil {
+		return marshalSnapshot(binaryHeap, valuesSnapshot[T]{})
+	}
+	return marshalSnapshot(binaryHeap, valuesSnapshot[T]{Values: *o.data})
+}
+func (o *Heap[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryHeap, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewHeap[T]()
+	}
+	if o.less == nil {
+		return errNoNaturalOrder[T]()
+	}
+	*o = o.WithSlice(s.Values)
+	return nil
+}
+
+// --------------------集合--------------------
+
+func (o HashSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(binaryHashSet, valuesSnapshot[T]{Values: o.m.KeySlice()})
+}
+func (o *HashSet[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryHashSet, &s); err != nil {
+		return err
+	}
+	if o.m.m == nil {
+		*o = NewHashSet[T]()
+	}
+	o.Clear()
+	for _, v := range s.Values {
+		o.Add(v)
+	}
+	return nil
+}
+
+// 访问顺序模式和最大容量保存在内部的LinkedHashMap中
+func (o LinkedHashSet[T]) MarshalBinary() ([]byte, error) {
+	return o.m.marshalBinary(binaryLinkedHashSet)
+}
+func (o *LinkedHashSet[T]) UnmarshalBinary(data []byte) error {
+	return o.m.unmarshalBinary(data, binaryLinkedHashSet)
+}
+
+// 按升序保存，解码时O(n)重建
+func (o TreeSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(binaryTreeSet, valuesSnapshot[T]{Values: o.m.KeySlice()})
+}
+func (o *TreeSet[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryTreeSet, &s); err != nil {
+		return err
+	}
+	if o.m.dummyRoot == nil {
+		m, err := newNaturalTreeMap[T, struct{}]()
+		if err != nil {
+			return err
+		}
+		*o = TreeSet[T]{m: m}
+	}
+	o.m.loadSorted(s.Values, make([]struct{}, len(s.Values)))
+	return nil
+}
+
+// 解码时需要先使用NewCustomHashSet创建集合，以提供Hasher
+func (o CustomHashSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSnapshot(binaryCustomHashSet, valuesSnapshot[T]{Values: o.ToSlice()})
+}
+func (o *CustomHashSet[T]) UnmarshalBinary(data []byte) error {
+	var s valuesSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryCustomHashSet, &s); err != nil {
+		return err
+	}
+	if o.m.m == nil {
+		return errNoHasher[T]()
+	}
+	o.Clear()
+	for _, v := range s.Values {
+		o.Add(v)
+	}
+	return nil
+}
+
+// --------------------多重集--------------------
+
+func (o MultiHashSet[T]) MarshalBinary() ([]byte, error) {
+	values, counts := unzipEntries(o.All())
+	return marshalSnapshot(binaryMultiHashSet, countsSnapshot[T]{Values: values, Counts: counts})
+}
+func (o *MultiHashSet[T]) UnmarshalBinary(data []byte) error {
+	var s countsSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryMultiHashSet, &s); err != nil {
+		return err
+	}
+	if len(s.Values) != len(s.Counts) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.total == nil {
+		*o = NewMultiHashSet[T]()
+	}
+	o.Clear()
+	for i, x := range s.Values {
+		o.AddN(x, s.Counts[i])
+	}
+	return nil
+}
+
+// 按升序保存，解码时O(n)重建
+func (o MultiTreeSet[T]) MarshalBinary() ([]byte, error) {
+	values, counts := unzipEntries(o.All())
+	return marshalSnapshot(binaryMultiTreeSet, countsSnapshot[T]{Values: values, Counts: counts})
+}
+func (o *MultiTreeSet[T]) UnmarshalBinary(data []byte) error {
+	var s countsSnapshot[T]
+	if err := unmarshalSnapshot(data, binaryMultiTreeSet, &s); err != nil {
+		return err
+	}
+	if len(s.Values) != len(s.Counts) || slices.ContainsFunc(s.Counts, func(cnt int) bool { return cnt <= 0 }) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.sumMap == nil {
+		c, err := naturalCmp[T]()
+		if err != nil {
+			return err
+		}
+		*o = NewMultiTreeSetFunc(c)
+	}
+	o.Clear()
+	o.m.loadSorted(s.Values, s.Counts)
+	return nil
+}
+
+// --------------------映射--------------------
+
+func (o HashMap[K, V]) MarshalBinary() ([]byte, error) {
+	keys, values := unzipEntries(o.All())
+	return marshalSnapshot(binaryHashMap, entriesSnapshot[K, V]{Keys: keys, Values: values})
+}
+func (o *HashMap[K, V]) UnmarshalBinary(data []byte) error {
+	var s entriesSnapshot[K, V]
+	if err := unmarshalSnapshot(data, binaryHashMap, &s); err != nil {
+		return err
+	}
+	if len(s.Keys) != len(s.Values) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.m == nil {
+		*o = NewHashMap[K, V]()
+	}
+	o.Clear()
+	for i, k := range s.Keys {
+		o.Set(k, s.Values[i])
+	}
+	return nil
+}
+
+// 保存链表顺序、访问顺序模式和最大容量
+func (o LinkedHashMap[K, V]) MarshalBinary() ([]byte, error) {
+	return o.marshalBinary(binaryLinkedHashMap)
+}
+func (o *LinkedHashMap[K, V]) UnmarshalBinary(data []byte) error {
+	return o.unmarshalBinary(data, binaryLinkedHashMap)
+}
+func (o LinkedHashMap[K, V]) marshalBinary(kind byte) ([]byte, error) {
+	s := entriesSnapshot[K, V]{AccessOrder: o.accessOrder, MaxCap: o.maxCap}
+	if o.m != nil {
+		s.Keys, s.Values = unzipEntries(o.All())
+	}
+	return marshalSnapshot(kind, s)
+}
+func (o *LinkedHashMap[K, V]) unmarshalBinary(data []byte, kind byte) error {
+	var s entriesSnapshot[K, V]
+	if err := unmarshalSnapshot(data, kind, &s); err != nil {
+		return err
+	}
+	if len(s.Keys) != len(s.Values) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.m == nil {
+		*o = NewLinkedHashMap[K, V]()
+	}
+	o.Clear()
+	o.accessOrder, o.maxCap = s.AccessOrder, s.MaxCap
+	for i, k := range s.Keys {
+		o.Set(k, s.Values[i])
+	}
+	return nil
+}
+
+// 按升序保存，解码时O(n)重建
+func (o TreeMap[K, V]) MarshalBinary() ([]byte, error) {
+	keys, values := unzipEntries(o.All())
+	return marshalSnapshot(binaryTreeMap, entriesSnapshot[K, V]{Keys: keys, Values: values})
+}
+func (o *TreeMap[K, V]) UnmarshalBinary(data []byte) error {
+	var s entriesSnapshot[K, V]
+	if err := unmarshalSnapshot(data, binaryTreeMap, &s); err != nil {
+		return err
+	}
+	if len(s.Keys) != len(s.Values) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.dummyRoot == nil {
+		m, err := newNaturalTreeMap[K, V]()
+		if err != nil {
+			return err
+		}
+		*o = m
+	}
+	o.loadSorted(s.Keys, s.Values)
+	return nil
+}
+
+// 快照中的键通常已经按当前比较函数升序排列，可以O(n)重建，否则逐个插入
+func (o TreeMap[K, V]) loadSorted(keys []K, values []V) {
+	o.Clear()
+	if o.setSorted(keys, values) {
+		return
+	}
+	for i, k := range keys {
+		o.Set(k, values[i])
+	}
+}
+
+// 解码时需要先使用NewCustomHashMap创建映射，以提供Hasher
+func (o CustomHashMap[K, V]) MarshalBinary() ([]byte, error) {
+	keys, values := unzipEntries(o.All())
+	return marshalSnapshot(binaryCustomHashMap, entriesSnapshot[K, V]{Keys: keys, Values: values})
+}
+func (o *CustomHashMap[K, V]) UnmarshalBinary(data []byte) error {
+	var s entriesSnapshot[K, V]
+	if err := unmarshalSnapshot(data, binaryCustomHashMap, &s); err != nil {
+		return err
+	}
+	if len(s.Keys) != len(s.Values) {
+		return fmt.Errorf("gods: 快照数据损坏")
+	}
+	if o.m == nil {
+		return errNoHasher[K]()
+	}
+	o.Clear()
+	for i, k := range s.Keys {
+		o.Set(k, s.Values[i])
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package gods
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test二进制快照(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for i := range 1000 {
+		m.Set(i, fmt.Sprint(i))
+	}
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m2 TreeMap[int, string]
+	if err := m2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if m2.Len() != 1000 || m2.Get(567) != "567" || m2.root().depth > 11 {
+		t.Errorf("TreeMap快照：长度%v 高度%v", m2.Len(), m2.root().depth)
+	}
+	if !sameFunc(m2.cmp, cmp.Compare[int]) || !m2.sameOrder(m) {
+		t.Errorf("解码零值TreeMap时应使用cmp.Compare")
+	}
+
+	lhm := NewLinkedHashMap[string, int]().WithAccessOrderMode().WithMaxCap(3)
+	lhm.Set("a", 1).Set("b", 2).Set("c", 3).Get("a")
+	b, _ = lhm.MarshalBinary()
+	var lhm2 LinkedHashMap[string, int]
+	if err := lhm2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	lhm2.Set("d", 4) // 容量已满，淘汰最久未访问的b
+	if !slices.Equal(lhm2.KeySlice(), []string{"c", "a", "d"}) {
+		t.Errorf("LinkedHashMap快照：%v", lhm2)
+	}
+
+	ms := NewMultiTreeSetFromSlice([]int{5, 1, 1, 3})
+	b, _ = ms.MarshalBinary()
+	var ms2 MultiTreeSet[int]
+	if err := ms2.UnmarshalBinary(b); err != nil || ms2.Total() != 4 || ms2.Select(3) != 3 {
+		t.Errorf("MultiTreeSet快照：%v %v", ms2, err)
+	}
+	b, _ = NewTreeSetFromSlice([]string{"b", "a"}).MarshalBinary()
+	var s2 TreeSet[string]
+	if err := s2.UnmarshalBinary(b); err != nil || !sameFunc(s2.m.cmp, cmp.Compare[string]) || !sameFunc(ms2.m.cmp, cmp.Compare[int]) {
+		t.Errorf("解码零值有序集合时应使用cmp.Compare：%v %v", s2, err)
+	}
+	b, _ = ms.MarshalBinary()
+
+	var arr Array[int]
+	if err := arr.UnmarshalBinary(b); err == nil {
+		t.Errorf("容器类型不匹配时应该返回错误")
+	}
+	b[4] = binaryVersion + 1
+	if err := ms2.UnmarshalBinary(b); err == nil {
+		t.Errorf("未知版本应该返回错误")
+	}
+}
+
+func TestGob编码(t *testing.T) {
+	type checkpoint struct {
+		Counts MultiHashSet[string]
+		Index  TreeMap[string, Array[int]]
+		Queue  Queue[int]
+		Set    TreeSet[float64]
+		Heap   Heap[int]
+	}
+	c := checkpoint{
+		Counts: NewMultiHashSetFromSlice([]string{"a", "b", "a"}),
+		Index:  NewTreeMap[string, Array[int]]().Set("x", ValArr(1, 2)).Set("y", ValArr(3)),
+		Queue:  NewQueue[int]().Push(1).Push(2),
+		Set:    NewTreeSetFromSlice([]float64{2.5, 1.5}),
+		Heap:   NewHeap[int]().Push(3).Push(1).Push(2),
+	}
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(c); err != nil {
+		t.Fatal(err)
+	}
+	var got checkpoint
+	if err := gob.NewDecoder(buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(got.Counts, got.Index, got.Queue, got.Set, got.Heap.ToSlice())
+	if got.Counts.Count("a") != 2 || got.Index.Get("x").Get(1) != 2 || got.Heap.Peek() != 1 {
+		t.Errorf("gob解码结果不一致")
+	}
+}
+
+func Test自定义哈希容器快照(t *testing.T) {
+	type checkpoint struct {
+		Seen  CustomHashSet[[]int]
+		Names CustomHashMap[[]int, string]
+	}
+	newCheckpoint := func() checkpoint {
+		return checkpoint{NewCustomHashSet(SliceHasher[int]()), NewCustomHashMap[[]int, string](SliceHasher[int]())}
+	}
+	c := newCheckpoint()
+	c.Seen.Add([]int{1, 2}).Add([]int{3})
+	c.Names.Set([]int{1}, "a").Set([]int{2, 3}, "b")
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(c); err != nil {
+		t.Fatal(err)
+	}
+	got := newCheckpoint() // 解码前需要提供Hasher
+	if err := gob.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Seen.Len() != 2 || !got.Seen.Has([]int{1, 2}) || got.Names.Len() != 2 || got.Names.Get([]int{2, 3}) != "b" {
+		t.Errorf("gob解码结果不一致：%v %v", got.Seen, got.Names)
+	}
+
+	var zero checkpoint
+	if err := gob.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&zero); err == nil {
+		t.Errorf("解码到零值应该返回错误")
+	}
+	b, _ := c.Names.MarshalBinary()
+	if err := got.Seen.UnmarshalBinary(b); err == nil {
+		t.Errorf("容器类型不匹配时应该返回错误")
+	}
+}
@@ -0,0 +1,209 @@
+package gods
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ===================================阻塞队列===================================
+
+// 队列关闭后，Put返回该错误；Take在取完剩余元素后返回该错误
+var ErrQueueClosed = errors.New("gods: 队列已关闭")
+
+// 并发安全的先进先出阻塞队列，容量小于等于0时不限制长度
+type BlockingQueue[T any] struct{ *blocking[T] }
+
+// 并发安全的阻塞优先队列，按堆的弹出顺序取出元素，容量小于等于0时不限制长度
+type BlockingPriorityQueue[T any] struct{ *blocking[T] }
+
+func NewBlockingQueue[T any](capacity int) BlockingQueue[T] {
+	q := NewQueue[T]()
+	return BlockingQueue[T]{newBlocking("BlockingQueue", capacity, q.Len, q.ToSlice,
+		func(x T) { q.PushBack(x) }, q.PopFront, q.PeekFront)}
+}
+
+// 创建按自然排序弹出最小元素的优先队列
+func NewBlockingPriorityQueue[T cmp.Ordered](capacity int) BlockingPriorityQueue[T] {
+	return newBlockingPriorityQueue(NewOrderedHeap[T](), capacity)
+}
+
+// 创建使用自定义less函数的优先队列
+func NewBlockingPriorityQueueFunc[T any](less func(T, T) bool, capacity int) BlockingPriorityQueue[T] {
+	return newBlockingPriorityQueue(NewHeap[T]().WithLess(less), capacity)
+}
+func newBlockingPriorityQueue[T any](h Heap[T], capacity int) BlockingPriorityQueue[T] {
+	return BlockingPriorityQueue[T]{newBlocking("BlockingPriorityQueue", capacity, h.Len, h.ToSlice,
+		func(x T) { h.push(x) }, h.Pop, h.Peek)}
+}
+
+// --------------------共用实现--------------------
+
+// 使用互斥锁保护内部容器，插入和取出分别只唤醒等待另一侧的一个协程
+// context结束时通过context.AfterFunc广播唤醒，只在真正需要阻塞时注册
+type blocking[T any] struct {
+	mu       sync.Mutex
+	notEmpty sync.Cond // 有新元素或者关闭时通知Take
+	notFull  sync.Cond // 有新空位或者关闭时通知Put
+	closed   bool
+	capacity int
+	name     string
+
+	// 内部容器的操作
+	len     func() int
+	toSlice func() []T
+	push    func(T)
+	pop     func() T
+	peek    func() T
+}
+
+func newBlocking[T any](name string, capacity int, length func() int, toSlice func() []T,
+	push func(T), pop, peek func() T) *blocking[T] {
+	o := &blocking[T]{capacity: capacity, name: name,
+		len: length, toSlice: toSlice, push: push, pop: pop, peek: peek}
+	o.notEmpty.L, o.notFull.L = &o.mu, &o.mu
+	return o
+}
+
+func (o *blocking[T]) full() bool    { return o.capacity > 0 && o.len() >= o.capacity }
+func (o *blocking[T]) canPut() bool  { return o.closed || !o.full() }
+func (o *blocking[T]) canTake() bool { return o.closed || o.len() > 0 }
+
+// 在cond上等待，直到ready返回true或者context结束，调用前后都持有锁
+func (o *blocking[T]) wait(ctx context.Context, cond *sync.Cond, ready func(*blocking[T]) bool) error {
+	if ready(o) {
+		return nil
+	}
+	stop := context.AfterFunc(ctx, func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		cond.Broadcast()
+	})
+	defer stop()
+	for !ready(o) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cond.Wait()
+	}
+	return nil
+}
+
+// --------------------Container接口--------------------
+func (o *blocking[T]) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.len()
+}
+func (o *blocking[T]) Cap() int { return o.capacity }
+func (o *blocking[T]) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.name + fmt.Sprint(o.toSlice())
+}
+func (o *blocking[T]) ToSlice() []T { // 按取出顺序
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.toSlice()
+}
+
+// --------------------阻塞操作--------------------
+
+// 插入元素，队列满时阻塞，直到有空位、队列关闭或者context结束
+func (o *blocking[T]) Put(ctx context.Context, x T) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.wait(ctx, &o.notFull, (*blocking[T]).canPut); err != nil {
+		return err
+	}
+	if o.closed {
+		return ErrQueueClosed
+	}
+	o.push(x)
+	o.notEmpty.Signal()
+	return nil
+}
+
+// 取出元素，队列空时阻塞，直到有元素、队列关闭或者context结束
+func (o *blocking[T]) Take(ctx context.Context) (T, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.wait(ctx, &o.notEmpty, (*blocking[T]).canTake); err != nil {
+		return *new(T), err
+	}
+	if o.len() == 0 { // 已关闭且没有剩余元素
+		return *new(T), ErrQueueClosed
+	}
+	x := o.pop()
+	o.notFull.Signal()
+	return x, nil
+}
+
+// --------------------非阻塞操作--------------------
+
+// 队列满或已关闭时返回false
+func (o *blocking[T]) TryPut(x T) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed || o.full() {
+		return false
+	}
+	o.push(x)
+	o.notEmpty.Signal()
+	return true
+}
+
+// 队列空时返回false
+func (o *blocking[T]) TryTake() (T, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.len() == 0 {
+		return *new(T), false
+	}
+	x := o.pop()
+	o.notFull.Signal()
+	return x, true
+}
+func (o *blocking[T]) TryPeek() (T, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.len() == 0 {
+		return *new(T), false
+	}
+	return o.peek(), true
+}
+
+// 将最多limit个元素按取出顺序移到数组末尾，limit小于等于0时移出所有元素，返回移出的个数
+func (o *blocking[T]) DrainTo(arr Array[T], limit int) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := o.len()
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	for range n {
+		arr.Push(o.pop())
+	}
+	if n > 0 {
+		o.notFull.Broadcast()
+	}
+	return n
+}
+
+// 关闭队列，之后不能插入元素，阻塞的Put会返回ErrQueueClosed，Take在取完剩余元素后返回ErrQueueClosed
+func (o *blocking[T]) Close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.closed {
+		o.closed = true
+		o.notEmpty.Broadcast()
+		o.notFull.Broadcast()
+	}
+}
+func (o *blocking[T]) IsClosed() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.closed
+}
@@ -0,0 +1,163 @@
+package gods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test阻塞队列(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	ctx := context.Background()
+	q.Put(ctx, 1)
+	q.Put(ctx, 2)
+	fmt.Println(q, q.Len(), q.Cap())
+	if q.TryPut(3) {
+		t.Errorf("队列已满，TryPut应返回false")
+	}
+
+	// 队列满时Put会阻塞到超时
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Put(timeout, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Put应超时，得到%v", err)
+	}
+
+	// 取出元素后阻塞的Put可以继续
+	done := make(chan error)
+	go func() { done <- q.Put(ctx, 3) }()
+	if x, _ := q.Take(ctx); x != 1 {
+		t.Errorf("Take错误")
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Put错误：%v", err)
+	}
+	if x, ok := q.TryPeek(); x != 2 || !ok {
+		t.Errorf("TryPeek错误")
+	}
+
+	arr := NewArray[int]()
+	fmt.Println("移出的个数：", q.DrainTo(arr, 0), arr)
+	if !slices.Equal(arr.ToSlice(), []int{2, 3}) || q.Len() != 0 {
+		t.Errorf("DrainTo错误")
+	}
+
+	// 关闭后阻塞的Take返回ErrQueueClosed
+	go func() {
+		_, err := q.Take(ctx)
+		done <- err
+	}()
+	time.Sleep(time.Millisecond)
+	q.Close()
+	if err := <-done; !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("关闭后Take应返回ErrQueueClosed，得到%v", err)
+	}
+	if err := q.Put(ctx, 1); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("关闭后Put应返回ErrQueueClosed")
+	}
+}
+
+func Test阻塞优先队列(t *testing.T) {
+	q := NewBlockingPriorityQueue[int](0)
+	for _, x := range []int{5, 1, 4, 2, 3} {
+		q.TryPut(x)
+	}
+	fmt.Println(q)
+	arr := NewArray[int]()
+	q.DrainTo(arr, 3)
+	if !slices.Equal(arr.ToSlice(), []int{1, 2, 3}) || q.Len() != 2 {
+		t.Errorf("DrainTo错误：%v", arr)
+	}
+	q.Close()
+	for _, want := range []int{4, 5} { // 关闭后仍能取出剩余元素
+		if x, err := q.Take(context.Background()); x != want || err != nil {
+			t.Errorf("Take错误：%v %v", x, err)
+		}
+	}
+
+	jobs := NewBlockingPriorityQueueFunc(func(a, b string) bool { return len(a) <= len(b) }, 0)
+	jobs.TryPut("ccc")
+	jobs.TryPut("a")
+	if x, _ := jobs.TryTake(); x != "a" {
+		t.Errorf("自定义less错误")
+	}
+}
+
+func Test阻塞队列生产者消费者(t *testing.T) {
+	q := NewBlockingQueue[int](8)
+	ctx := context.Background()
+	const producers, n = 4, 1000
+	var wg, consumers sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range n {
+				if err := q.Put(ctx, p*n+i); err != nil {
+					t.Errorf("Put错误：%v", err)
+				}
+			}
+		}()
+	}
+	results := make([][]int, 3)
+	for c := range results {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				x, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+				results[c] = append(results[c], x)
+			}
+		}()
+	}
+	wg.Wait()
+	q.Close()
+	consumers.Wait()
+
+	all := slices.Sorted(slices.Values(slices.Concat(results...)))
+	if len(all) != producers*n || all[0] != 0 || all[len(all)-1] != producers*n-1 {
+		t.Errorf("消费的元素错误，共%d个", len(all))
+	}
+}
+
+func Test阻塞队列取消等待(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx := context.Background()
+
+	// 取消一个等待的Take后，新元素仍能交给另一个等待者
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancelled, taken := make(chan error), make(chan int)
+	go func() {
+		_, err := q.Take(cancelCtx)
+		cancelled <- err
+	}()
+	go func() {
+		x, _ := q.Take(ctx)
+		taken <- x
+	}()
+	time.Sleep(time.Millisecond)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("取消后Take应返回context.Canceled，得到%v", err)
+	}
+	q.Put(ctx, 7)
+	if x := <-taken; x != 7 {
+		t.Errorf("Take错误：%v", x)
+	}
+
+	// 不阻塞时Put和Take不分配内存
+	allocs := testing.AllocsPerRun(100, func() {
+		q.Put(ctx, 1)
+		q.Take(ctx)
+	})
+	if allocs != 0 {
+		t.Errorf("Put和Take分配了%v次内存", allocs)
+	}
+}
@@ -0,0 +1,637 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ===================================B树节点===================================
+
+// 每个节点连续存放多个键值对，叶子节点的children为nil
+type btreeNode[K comparable, V any] struct {
+	keys     []K
+	vals     []V
+	children []*btreeNode[K, V]
+	size     int // 子树中键值对的个数
+}
+
+func (o *btreeNode[K, V]) leaf() bool { return o.children == nil }
+
+func (o *btreeNode[K, V]) childSize(i int) int {
+	if o.leaf() {
+		return 0
+	}
+	return o.children[i].size
+}
+
+func (o *btreeNode[K, V]) deepCopy() *btreeNode[K, V] {
+	if o == nil {
+		return nil
+	}
+	res := &btreeNode[K, V]{keys: slices.Clone(o.keys), vals: slices.Clone(o.vals), size: o.size}
+	if !o.leaf() {
+		res.children = make([]*btreeNode[K, V], len(o.children), cap(o.children))
+		for i, child := range o.children {
+			res.children[i] = child.deepCopy()
+		}
+	}
+	return res
+}
+
+// 从第一个满足pred的键开始升序遍历子树，pred为nil时遍历整棵子树，pred需要满足单调性
+func (o *btreeNode[K, V]) ascendWhere(pred func(K) bool, yield func(K, V) bool) bool {
+	i := 0
+	if pred != nil {
+		i = sort.Search(len(o.keys), func(j int) bool { return pred(o.keys[j]) })
+	}
+	if !o.leaf() && !o.children[i].ascendWhere(pred, yield) {
+		return false
+	}
+	for ; i < len(o.keys); i++ {
+		if !yield(o.keys[i], o.vals[i]) {
+			return false
+		}
+		if !o.leaf() && !o.children[i+1].ascendWhere(nil, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// 从最后一个满足pred的键开始降序遍历子树，pred为nil时遍历整棵子树，pred需要满足单调性
+func (o *btreeNode[K, V]) descendWhere(pred func(K) bool, yield func(K, V) bool) bool {
+	i := len(o.keys)
+	if pred != nil {
+		i = sort.Search(len(o.keys), func(j int) bool { return !pred(o.keys[j]) })
+	}
+	if !o.leaf() && !o.children[i].descendWhere(pred, yield) {
+		return false
+	}
+	for i--; i >= 0; i-- {
+		if !yield(o.keys[i], o.vals[i]) {
+			return false
+		}
+		if !o.leaf() && !o.children[i].descendWhere(nil, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// ===================================基于B树的有序映射===================================
+
+// 每个节点存放多个键值对的有序映射，节点个数远少于TreeMap，对缓存更友好，适用于键很多的场景
+// 节点中记录子树大小，支持O(logn)的排名查询，返回的MapEntry没有左右节点
+type BTreeMap[K comparable, V any] struct {
+	t       *btree[K, V]
+	cmp     func(K, K) int // 键的比较函数
+	factory func() V
+}
+
+type btree[K comparable, V any] struct {
+	root   *btreeNode[K, V]
+	degree int // 最小度数，除根节点外每个节点有degree-1到2*degree-1个键
+}
+
+const defaultBTreeDegree = 32
+
+// 创建按自然顺序排序的映射
+func NewBTreeMap[K cmp.Ordered, V any]() BTreeMap[K, V] { return NewBTreeMapFunc[K, V](cmp.Compare[K]) }
+
+// 创建使用自定义比较函数排序的映射，cmp返回负数、0、正数分别表示小于、等于、大于
+func NewBTreeMapFunc[K comparable, V any](cmp func(K, K) int) BTreeMap[K, V] {
+	return BTreeMap[K, V]{t: &btree[K, V]{degree: defaultBTreeDegree}, cmp: cmp}
+}
+
+// 创建具有默认值的映射，在调用get时，若key不存在，则使用factory函数设置值
+func (o BTreeMap[K, V]) WithFactory(factory func() V) BTreeMap[K, V] {
+	o.factory = factory
+	return o
+}
+
+// 设置节点的最小度数，必须在映射的长度为0时调用，度数越大树越矮，但节点内的移动越多
+func (o BTreeMap[K, V]) WithDegree(degree int) BTreeMap[K, V] {
+	if o.Len() != 0 {
+		panic("映射必须空，才能设置度数")
+	}
+	if degree < 2 {
+		panic(fmt.Sprintf("B树的度数必须大于等于2：%d", degree))
+	}
+	o.t.degree = degree
+	return o
+}
+
+func (o BTreeMap[K, V]) root() *btreeNode[K, V] {
+	if o.t == nil { // 零值映射
+		return nil
+	}
+	return o.t.root
+}
+func (o BTreeMap[K, V]) maxKeys() int { return 2*o.t.degree - 1 }
+
+// 创建节点，预留最多键数的容量，避免插入时重新分配
+func (o BTreeMap[K, V]) newNode(leaf bool) *btreeNode[K, V] {
+	node := &btreeNode[K, V]{keys: make([]K, 0, o.maxKeys()), vals: make([]V, 0, o.maxKeys())}
+	if !leaf {
+		node.children = make([]*btreeNode[K, V], 0, o.maxKeys()+1)
+	}
+	return node
+}
+
+// 键在节点中的位置，不存在时返回应插入的位置和false
+func (o BTreeMap[K, V]) search(node *btreeNode[K, V], k K) (int, bool) {
+	return slices.BinarySearchFunc(node.keys, k, o.cmp)
+}
+
+// ==============Container接口============= */
+func (o BTreeMap[K, V]) Len() int {
+	if o.root() == nil {
+		return 0
+	}
+	return o.root().size
+}
+func (o BTreeMap[K, V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "BTreeMap[" + strings.Join(entries, " ") + "]"
+}
+func (o BTreeMap[K, V]) Clear() BTreeMap[K, V] { o.t.root = nil; return o }
+func (o BTreeMap[K, V]) Clone() BTreeMap[K, V] { // 复制所有节点，代价为O(n)
+	o.t = &btree[K, V]{root: o.root().deepCopy(), degree: o.t.degree}
+	return o
+}
+
+// ==============MapContainer接口=============
+// 遍历和转换
+func (o BTreeMap[K, V]) ForEach(f func(k K, v V)) BTreeMap[K, V] {
+	keys, values := make([]K, 0, o.Len()), make([]V, 0, o.Len())
+	for k, v := range o.All() {
+		keys, values = append(keys, k), append(values, v)
+	}
+	for i, k := range keys { // 支持边遍历边修改
+		f(k, values[i])
+	}
+	return o
+}
+func (o BTreeMap[K, V]) ToMap() map[K]V {
+	m := make(map[K]V, o.Len())
+	for k, v := range o.All() {
+		m[k] = v
+	}
+	return m
+}
+
+// 按键升序惰性遍历，遍历过程中不能修改映射，需要修改时使用ForEach
+func (o BTreeMap[K, V]) All() iter.Seq2[K, V] { return o.ascendWhere(nil) }
+func (o BTreeMap[K, V]) Keys() iter.Seq[K]    { return seqKeys(o.All()) }
+func (o BTreeMap[K, V]) Values() iter.Seq[V]  { return seqValues(o.All()) }
+
+// 按键降序惰性遍历
+func (o BTreeMap[K, V]) Backward() iter.Seq2[K, V] { return o.descendWhere(nil) }
+
+// 从第一个>=k的键开始升序惰性遍历
+func (o BTreeMap[K, V]) AscendFrom(k K) iter.Seq2[K, V] {
+	return o.ascendWhere(func(key K) bool { return o.cmp(key, k) >= 0 })
+}
+
+// 从最后一个<=k的键开始降序惰性遍历
+func (o BTreeMap[K, V]) DescendFrom(k K) iter.Seq2[K, V] {
+	return o.descendWhere(func(key K) bool { return o.cmp(key, k) <= 0 })
+}
+
+// 升序惰性遍历lo到hi之间的键，由loInclusive和hiInclusive决定是否包含边界
+func (o BTreeMap[K, V]) Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		nodes := o.ascendWhere(func(key K) bool {
+			c := o.cmp(key, lo)
+			return c > 0 || loInclusive && c == 0
+		})
+		for k, v := range nodes {
+			if c := o.cmp(k, hi); c > 0 || !hiInclusive && c == 0 {
+				return
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+func (o BTreeMap[K, V]) ascendWhere(pred func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if root := o.root(); root != nil {
+			root.ascendWhere(pred, yield)
+		}
+	}
+}
+func (o BTreeMap[K, V]) descendWhere(pred func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if root := o.root(); root != nil {
+			root.descendWhere(pred, yield)
+		}
+	}
+}
+
+// 基本操作
+func (o BTreeMap[K, V]) Get(k K) V {
+	if v, ok := o.Lookup(k); ok {
+		return v
+	}
+	if o.factory != nil { // 使用工厂函数设置值
+		v := o.factory()
+		o.Set(k, v)
+		return v
+	}
+	return *new(V)
+}
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o BTreeMap[K, V]) Lookup(k K) (V, bool) {
+	for node := o.root(); node != nil; {
+		i, ok := o.search(node, k)
+		if ok {
+			return node.vals[i], true
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	return *new(V), false
+}
+func (o BTreeMap[K, V]) Has(k K) bool { _, ok := o.Lookup(k); return ok }
+
+// 自顶向下插入，经过的满节点会被提前分裂，因此不需要回溯
+func (o BTreeMap[K, V]) Set(k K, v V) BTreeMap[K, V] {
+	root := o.root()
+	if root == nil {
+		root = o.newNode(true)
+		o.t.root = root
+	} else if len(root.keys) == o.maxKeys() { // 根节点已满，树高加1
+		newRoot := o.newNode(false)
+		newRoot.children = append(newRoot.children, root)
+		newRoot.size = root.size
+		o.splitChild(newRoot, 0)
+		o.t.root = newRoot
+	}
+	o.insert(o.t.root, k, v)
+	return o
+}
+
+// 在未满的节点的子树中插入，键不存在时返回true
+func (o BTreeMap[K, V]) insert(node *btreeNode[K, V], k K, v V) bool {
+	i, ok := o.search(node, k)
+	if ok {
+		node.vals[i] = v
+		return false
+	}
+	if node.leaf() {
+		node.keys = slices.Insert(node.keys, i, k)
+		node.vals = slices.Insert(node.vals, i, v)
+		node.size++
+		return true
+	}
+	if len(node.children[i].keys) == o.maxKeys() {
+		o.splitChild(node, i)
+		if c := o.cmp(k, node.keys[i]); c == 0 { // 上移的中间键恰好是k
+			node.vals[i] = v
+			return false
+		} else if c > 0 {
+			i++
+		}
+	}
+	if o.insert(node.children[i], k, v) {
+		node.size++
+		return true
+	}
+	return false
+}
+
+// 将已满的第i个子节点分裂为两个，中间的键上移到node中
+func (o BTreeMap[K, V]) splitChild(node *btreeNode[K, V], i int) {
+	left, t := node.children[i], o.t.degree
+	right := o.newNode(left.leaf())
+	right.keys = append(right.keys, left.keys[t:]...)
+	right.vals = append(right.vals, left.vals[t:]...)
+	right.size = len(right.keys)
+	if !left.leaf() {
+		right.children = append(right.children, left.children[t:]...)
+		for _, child := range right.children {
+			right.size += child.size
+		}
+		clear(left.children[t:])
+		left.children = left.children[:t]
+	}
+	midK, midV := left.keys[t-1], left.vals[t-1]
+	clear(left.keys[t-1:]) // 释放被移走的键值对的引用
+	clear(left.vals[t-1:])
+	left.keys, left.vals = left.keys[:t-1], left.vals[:t-1]
+	left.size -= right.size + 1
+
+	node.keys = slices.Insert(node.keys, i, midK)
+	node.vals = slices.Insert(node.vals, i, midV)
+	node.children = slices.Insert(node.children, i+1, right)
+}
+
+// 自顶向下删除，进入子节点前保证它至少有degree个键，因此不需要回溯
+func (o BTreeMap[K, V]) Del(k K) BTreeMap[K, V] {
+	root := o.root()
+	if root == nil {
+		return o
+	}
+	o.remove(root, k)
+	if len(root.keys) == 0 { // 根节点为空，树高减1
+		if root.leaf() {
+			o.t.root = nil
+		} else {
+			o.t.root = root.children[0]
+		}
+	}
+	return o
+}
+
+// 在子树中删除键，键存在时返回true
+func (o BTreeMap[K, V]) remove(node *btreeNode[K, V], k K) bool {
+	i, ok := o.search(node, k)
+	if node.leaf() {
+		if !ok {
+			return false
+		}
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.vals = slices.Delete(node.vals, i, i+1)
+		node.size--
+		return true
+	}
+	t := o.t.degree
+	if ok {
+		if left := node.children[i]; len(left.keys) >= t { // 使用前驱替换
+			pred := left
+			for !pred.leaf() {
+				pred = pred.children[len(pred.children)-1]
+			}
+			node.keys[i], node.vals[i] = pred.keys[len(pred.keys)-1], pred.vals[len(pred.vals)-1]
+			o.remove(left, node.keys[i])
+		} else if right := node.children[i+1]; len(right.keys) >= t { // 使用后继替换
+			succ := right
+			for !succ.leaf() {
+				succ = succ.children[0]
+			}
+			node.keys[i], node.vals[i] = succ.keys[0], succ.vals[0]
+			o.remove(right, node.keys[i])
+		} else { // 两个子节点都只有degree-1个键，合并后在子节点中删除
+			o.merge(node, i)
+			o.remove(node.children[i], k)
+		}
+		node.size--
+		return true
+	}
+	if len(node.children[i].keys) < t {
+		i = o.fill(node, i)
+	}
+	if o.remove(node.children[i], k) {
+		node.size--
+		return true
+	}
+	return false
+}
+
+// 让只有degree-1个键的第i个子节点至少有degree个键，返回该子节点新的位置
+func (o BTreeMap[K, V]) fill(node *btreeNode[K, V], i int) int {
+	t := o.t.degree
+	if i > 0 && len(node.children[i-1].keys) >= t {
+		o.borrowFromLeft(node, i)
+	} else if i < len(node.children)-1 && len(node.children[i+1].keys) >= t {
+		o.borrowFromRight(node, i)
+	} else if i < len(node.children)-1 {
+		o.merge(node, i)
+	} else {
+		o.merge(node, i-1)
+		i--
+	}
+	return i
+}
+
+// 经过node将左兄弟的最后一个键移到第i个子节点
+func (o BTreeMap[K, V]) borrowFromLeft(node *btreeNode[K, V], i int) {
+	child, left := node.children[i], node.children[i-1]
+	last := len(left.keys) - 1
+	child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+	child.vals = slices.Insert(child.vals, 0, node.vals[i-1])
+	node.keys[i-1], node.vals[i-1] = left.keys[last], left.vals[last]
+	left.keys[last], left.vals[last] = *new(K), *new(V)
+	left.keys, left.vals = left.keys[:last], left.vals[:last]
+	moved := 1
+	if !child.leaf() {
+		grandchild := left.children[last+1]
+		child.children = slices.Insert(child.children, 0, grandchild)
+		left.children[last+1] = nil
+		left.children = left.children[:last+1]
+		moved += grandchild.size
+	}
+	child.size += moved
+	left.size -= moved
+}
+
+// 经过node将右兄弟的第一个键移到第i个子节点
+func (o BTreeMap[K, V]) borrowFromRight(node *btreeNode[K, V], i int) {
+	child, right := node.children[i], node.children[i+1]
+	child.keys = append(child.keys, node.keys[i])
+	child.vals = append(child.vals, node.vals[i])
+	node.keys[i], node.vals[i] = right.keys[0], right.vals[0]
+	right.keys = slices.Delete(right.keys, 0, 1)
+	right.vals = slices.Delete(right.vals, 0, 1)
+	moved := 1
+	if !child.leaf() {
+		grandchild := right.children[0]
+		child.children = append(child.children, grandchild)
+		right.children = slices.Delete(right.children, 0, 1)
+		moved += grandchild.size
+	}
+	child.size += moved
+	right.size -= moved
+}
+
+// 将node的第i个键和第i+1个子节点合并到第i个子节点中
+func (o BTreeMap[K, V]) merge(node *btreeNode[K, V], i int) {
+	left, right := node.children[i], node.children[i+1]
+	left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+	left.vals = append(append(left.vals, node.vals[i]), right.vals...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+	left.size += right.size + 1
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.vals = slices.Delete(node.vals, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// 组合操作
+func (o BTreeMap[K, V]) GetOr(k K, defalutValue V) V {
+	if v, ok := o.Lookup(k); ok {
+		return v
+	}
+	return defalutValue
+}
+func (o BTreeMap[K, V]) GetOrSet(k K, v V) V {
+	if res, ok := o.Lookup(k); ok {
+		return res
+	}
+	o.Set(k, v)
+	return v
+}
+func (o BTreeMap[K, V]) Extend(other BTreeMap[K, V]) BTreeMap[K, V] { // 键相同时使用other的值
+	other.ForEach(func(k K, v V) { o.Set(k, v) })
+	return o
+}
+func (o BTreeMap[K, V]) DelFunc(f func(K, V) bool) BTreeMap[K, V] {
+	return o.ForEach(func(k K, v V) {
+		if f(k, v) {
+			o.Del(k)
+		}
+	})
+}
+func (o BTreeMap[K, V]) ReplaceFunc(f func(K, V) V) BTreeMap[K, V] { // 直接修改节点中的值，不改变树结构
+	var _replace func(node *btreeNode[K, V])
+	_replace = func(node *btreeNode[K, V]) {
+		for i := range node.keys {
+			if !node.leaf() {
+				_replace(node.children[i])
+			}
+			node.vals[i] = f(node.keys[i], node.vals[i])
+		}
+		if !node.leaf() {
+			_replace(node.children[len(node.keys)])
+		}
+	}
+	if root := o.root(); root != nil {
+		_replace(root)
+	}
+	return o
+}
+
+// 键值列表查询
+func (o BTreeMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o BTreeMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
+
+// ==============TreeMapContainer接口=============
+// 二分查找键值对，不存在时返回nil
+func (o BTreeMap[K, V]) First() *MapEntry[K, V] {
+	node := o.root()
+	if node == nil {
+		return nil
+	}
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	return &MapEntry[K, V]{K: node.keys[0], V: node.vals[0]}
+}
+func (o BTreeMap[K, V]) Last() *MapEntry[K, V] {
+	node := o.root()
+	if node == nil {
+		return nil
+	}
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+	last := len(node.keys) - 1
+	return &MapEntry[K, V]{K: node.keys[last], V: node.vals[last]}
+}
+func (o BTreeMap[K, V]) Lower(k K) *MapEntry[K, V] {
+	return o.lastWhere(func(key K) bool { return o.cmp(key, k) < 0 })
+}
+func (o BTreeMap[K, V]) Higher(k K) *MapEntry[K, V] {
+	return o.firstWhere(func(key K) bool { return o.cmp(key, k) > 0 })
+}
+func (o BTreeMap[K, V]) Floor(k K) *MapEntry[K, V] {
+	return o.lastWhere(func(key K) bool { return o.cmp(key, k) <= 0 })
+}
+func (o BTreeMap[K, V]) Ceiling(k K) *MapEntry[K, V] {
+	return o.firstWhere(func(key K) bool { return o.cmp(key, k) >= 0 })
+}
+
+// 最后一个满足pred的键值对，pred对较小的键为true
+func (o BTreeMap[K, V]) lastWhere(pred func(K) bool) *MapEntry[K, V] {
+	var res *MapEntry[K, V]
+	for node := o.root(); node != nil; {
+		i := sort.Search(len(node.keys), func(j int) bool { return !pred(node.keys[j]) })
+		if i > 0 {
+			res = &MapEntry[K, V]{K: node.keys[i-1], V: node.vals[i-1]}
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[i] // 寻找更大的键
+	}
+	return res
+}
+
+// 第一个满足pred的键值对，pred对较大的键为true
+func (o BTreeMap[K, V]) firstWhere(pred func(K) bool) *MapEntry[K, V] {
+	var res *MapEntry[K, V]
+	for node := o.root(); node != nil; {
+		i := sort.Search(len(node.keys), func(j int) bool { return pred(node.keys[j]) })
+		if i < len(node.keys) {
+			res = &MapEntry[K, V]{K: node.keys[i], V: node.vals[i]}
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[i] // 寻找更小的键
+	}
+	return res
+}
+
+// 排名相关操作
+func (o BTreeMap[K, V]) Select(i int) *MapEntry[K, V] { // 第i个键值对，i从1开始，超出范围时返回nil
+	if i <= 0 || i > o.Len() {
+		return nil
+	}
+	for node := o.root(); ; {
+		j := 0
+		for ; j < len(node.keys); j++ {
+			if s := node.childSize(j); i <= s {
+				break
+			} else if i -= s; i == 1 {
+				return &MapEntry[K, V]{K: node.keys[j], V: node.vals[j]}
+			}
+			i--
+		}
+		node = node.children[j]
+	}
+}
+func (o BTreeMap[K, V]) Rank(k K) int { // 返回小于等于k的键的个数
+	cnt := 0
+	for node := o.root(); node != nil; {
+		i, ok := o.search(node, k)
+		for j := range i {
+			cnt += node.childSize(j) + 1
+		}
+		if ok {
+			return cnt + node.childSize(i) + 1
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	return cnt
+}
+
+// lo到hi之间的键的个数，由loInclusive和hiInclusive决定是否包含边界，O(logn)
+func (o BTreeMap[K, V]) CountRange(lo, hi K, loInclusive, hiInclusive bool) int {
+	hiCnt := o.Rank(hi)
+	if !hiInclusive && o.Has(hi) {
+		hiCnt--
+	}
+	loCnt := o.Rank(lo)
+	if loInclusive && o.Has(lo) {
+		loCnt--
+	}
+	return max(0, hiCnt-loCnt)
+}
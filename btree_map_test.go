@@ -0,0 +1,318 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/viocha/gods/container"
+)
+
+// 检查B树的有序性、节点键数、叶子深度和子树大小
+func checkBTree[K comparable, V any](t *testing.T, o BTreeMap[K, V]) {
+	leafDepth := -1
+	var check func(node *btreeNode[K, V], depth int) int
+	check = func(node *btreeNode[K, V], depth int) int {
+		if n := len(node.keys); n > o.maxKeys() || node != o.root() && n < o.t.degree-1 || n == 0 {
+			t.Fatalf("节点的键数错误：%v", node.keys)
+		}
+		for i := 1; i < len(node.keys); i++ {
+			if o.cmp(node.keys[i-1], node.keys[i]) >= 0 {
+				t.Fatalf("节点的顺序错误：%v", node.keys)
+			}
+		}
+		size := len(node.keys)
+		if node.leaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("叶子节点的深度不同")
+			}
+		} else {
+			if len(node.children) != len(node.keys)+1 {
+				t.Fatalf("子节点个数错误：%v", node.keys)
+			}
+			for i, child := range node.children {
+				if i > 0 && o.cmp(child.keys[0], node.keys[i-1]) <= 0 ||
+					i < len(node.keys) && o.cmp(child.keys[len(child.keys)-1], node.keys[i]) >= 0 {
+					t.Fatalf("子节点的顺序错误：%v", child.keys)
+				}
+				size += check(child, depth+1)
+			}
+		}
+		if node.size != size {
+			t.Fatalf("节点%v的大小错误", node.keys)
+		}
+		return size
+	}
+	if o.root() != nil {
+		check(o.root(), 0)
+	}
+}
+
+// --------------------有序映射一致性测试--------------------
+
+// TreeMap和BTreeMap共同实现的接口
+type conformanceMap[M any] interface {
+	container.TreeMapContainer[int, int, *MapEntry[int, int]]
+	container.Map[int, int, M]
+	CountRange(lo, hi int, loInclusive, hiInclusive bool) int
+}
+
+// 对有序映射做随机操作，并与有序切片的结果比较，check用于检查内部结构
+func testOrderedMapConformance[M conformanceMap[M]](t *testing.T, m M, check func(M)) {
+	const n = 300
+	keys := []int{} // 升序的键
+	vals := map[int]int{}
+	entryKey := func(e *MapEntry[int, int]) int {
+		if e == nil {
+			return -1
+		}
+		if e.V != vals[e.K] {
+			t.Fatalf("键%d的值错误：%d", e.K, e.V)
+		}
+		return e.K
+	}
+	// 满足pred的第一个和最后一个键，不存在时返回-1
+	first := func(pred func(int) bool) int {
+		if i := slices.IndexFunc(keys, pred); i >= 0 {
+			return keys[i]
+		}
+		return -1
+	}
+	last := func(pred func(int) bool) int {
+		for i := len(keys) - 1; i >= 0; i-- {
+			if pred(keys[i]) {
+				return keys[i]
+			}
+		}
+		return -1
+	}
+	inRange := func(k, lo, hi int, loInc, hiInc bool) bool {
+		return (k > lo || loInc && k == lo) && (k < hi || hiInc && k == hi)
+	}
+
+	for step := range 5000 {
+		k := rand.Intn(n)
+		switch op := rand.Intn(10); {
+		case op < 5:
+			m.Set(k, step)
+			if i, ok := slices.BinarySearch(keys, k); !ok {
+				keys = slices.Insert(keys, i, k)
+			}
+			vals[k] = step
+		case op < 9:
+			m.Del(k)
+			if i, ok := slices.BinarySearch(keys, k); ok {
+				keys = slices.Delete(keys, i, i+1)
+			}
+			delete(vals, k)
+		default:
+			m.DelFunc(func(k, v int) bool { return v%7 == 0 })
+			keys = slices.DeleteFunc(keys, func(k int) bool {
+				if vals[k]%7 == 0 {
+					delete(vals, k)
+					return true
+				}
+				return false
+			})
+		}
+		if step%50 == 0 {
+			check(m)
+		}
+
+		if m.Len() != len(keys) || !slices.Equal(m.KeySlice(), keys) {
+			t.Fatalf("第%d步后的键错误：%v，应为%v", step, m.KeySlice(), keys)
+		}
+		if v, ok := m.Lookup(k); ok != m.Has(k) || ok && v != vals[k] || m.GetOr(k, -1) != vals[k] && ok {
+			t.Fatalf("查找%d错误", k)
+		}
+
+		// 二分查找
+		if len(keys) > 0 && (entryKey(m.First()) != keys[0] || entryKey(m.Last()) != keys[len(keys)-1]) {
+			t.Fatalf("First或Last错误")
+		}
+		q := rand.Intn(n+2) - 1
+		if got, want := entryKey(m.Floor(q)), last(func(x int) bool { return x <= q }); got != want {
+			t.Fatalf("Floor(%d)=%d，应为%d", q, got, want)
+		}
+		if got, want := entryKey(m.Lower(q)), last(func(x int) bool { return x < q }); got != want {
+			t.Fatalf("Lower(%d)=%d，应为%d", q, got, want)
+		}
+		if got, want := entryKey(m.Ceiling(q)), first(func(x int) bool { return x >= q }); got != want {
+			t.Fatalf("Ceiling(%d)=%d，应为%d", q, got, want)
+		}
+		if got, want := entryKey(m.Higher(q)), first(func(x int) bool { return x > q }); got != want {
+			t.Fatalf("Higher(%d)=%d，应为%d", q, got, want)
+		}
+
+		// 排名
+		rank, _ := slices.BinarySearch(keys, q+1)
+		if m.Rank(q) != rank {
+			t.Fatalf("Rank(%d)=%d，应为%d", q, m.Rank(q), rank)
+		}
+		i := rand.Intn(len(keys)+2) - 1
+		want := -1
+		if i >= 1 && i <= len(keys) {
+			want = keys[i-1]
+		}
+		if got := entryKey(m.Select(i)); got != want {
+			t.Fatalf("Select(%d)=%d，应为%d", i, got, want)
+		}
+
+		// 迭代器
+		lo, hi := rand.Intn(n), rand.Intn(n)
+		loInc, hiInc := rand.Intn(2) == 0, rand.Intn(2) == 0
+		var wantRange []int
+		for _, k := range keys {
+			if inRange(k, lo, hi, loInc, hiInc) {
+				wantRange = append(wantRange, k)
+			}
+		}
+		if got := slices.Collect(seqKeys(m.Range(lo, hi, loInc, hiInc))); !slices.Equal(got, wantRange) {
+			t.Fatalf("Range(%d, %d, %v, %v)=%v，应为%v", lo, hi, loInc, hiInc, got, wantRange)
+		}
+		if m.CountRange(lo, hi, loInc, hiInc) != len(wantRange) {
+			t.Fatalf("CountRange(%d, %d, %v, %v)错误", lo, hi, loInc, hiInc)
+		}
+		from := slices.Collect(seqKeys(m.AscendFrom(q)))
+		if j, _ := slices.BinarySearch(keys, q); !slices.Equal(from, keys[j:]) {
+			t.Fatalf("AscendFrom(%d)错误：%v", q, from)
+		}
+		down := slices.Collect(seqKeys(m.DescendFrom(q)))
+		wantDown := slices.Clone(keys[:rank])
+		slices.Reverse(wantDown)
+		if !slices.Equal(down, wantDown) {
+			t.Fatalf("DescendFrom(%d)错误：%v", q, down)
+		}
+	}
+
+	backward := slices.Collect(seqKeys(m.Backward()))
+	slices.Reverse(backward)
+	if !slices.Equal(backward, keys) {
+		t.Errorf("Backward错误")
+	}
+	// 提前结束迭代
+	for k := range m.AscendFrom(n / 2) {
+		if k < n/2 {
+			t.Errorf("AscendFrom错误")
+		}
+		break
+	}
+
+	// 组合操作
+	clone := m.Clone()
+	m.ReplaceFunc(func(k, v int) int { return -k })
+	if v, _ := m.Lookup(keys[0]); v != -keys[0] {
+		t.Errorf("ReplaceFunc错误")
+	}
+	if v, _ := clone.Lookup(keys[0]); v != vals[keys[0]] {
+		t.Errorf("Clone后修改影响了副本")
+	}
+	if m.GetOrSet(n, 1) != 1 || m.GetOrSet(n, 2) != 1 {
+		t.Errorf("GetOrSet错误")
+	}
+	extended := m.Clear().Extend(clone)
+	if !slices.Equal(extended.KeySlice(), keys) || !slices.Equal(m.KeySlice(), keys) || m.Has(n) {
+		t.Errorf("Extend错误")
+	}
+	if extended.Set(n, 0); !m.Has(n) || clone.Has(n) {
+		t.Errorf("Extend应返回o自身")
+	}
+	m.Del(n)
+	check(m)
+	for _, k := range rand.Perm(n) {
+		m.Del(k)
+	}
+	if m.Len() != 0 || m.First() != nil || m.Select(1) != nil {
+		t.Errorf("删除所有键后应为空")
+	}
+}
+
+func Test有序映射一致性(t *testing.T) {
+	t.Run("TreeMap", func(t *testing.T) {
+		testOrderedMapConformance(t, NewTreeMap[int, int](), func(m TreeMap[int, int]) { checkAVL(t, m.root(), m.cmp) })
+	})
+	for _, degree := range []int{2, 3, 8, defaultBTreeDegree} {
+		t.Run(fmt.Sprintf("BTreeMap度数%d", degree), func(t *testing.T) {
+			testOrderedMapConformance(t, NewBTreeMap[int, int]().WithDegree(degree), func(m BTreeMap[int, int]) { checkBTree(t, m) })
+		})
+	}
+}
+
+func TestB树映射(t *testing.T) {
+	m := NewBTreeMap[string, int]().WithDegree(2)
+	for i, s := range []string{"d", "b", "a", "c", "f", "e"} {
+		m.Set(s, i)
+	}
+	fmt.Println(m)
+	if m.String() != "BTreeMap[a:2 b:1 c:3 d:0 e:5 f:4]" {
+		t.Errorf("字符串表示错误：%v", m)
+	}
+	if e := m.Floor("cc"); e.K != "c" || e.Left() != nil {
+		t.Errorf("Floor错误")
+	}
+	if m.Rank("c") != 3 || m.Select(5).K != "e" {
+		t.Errorf("排名错误")
+	}
+
+	m = NewBTreeMap[string, int]().WithFactory(func() int { return 100 })
+	if m.Get("x") != 100 || m.Len() != 1 {
+		t.Errorf("工厂函数错误")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("非空映射设置度数应panic")
+		}
+	}()
+	m.WithDegree(4)
+}
+
+// --------------------TreeMap和BTreeMap的性能比较--------------------
+
+const benchmarkMapSize = 1 << 20
+
+func benchmarkMapInsert[M container.Map[int, int, M]](b *testing.B, newMap func() M) {
+	keys := rand.Perm(benchmarkMapSize)
+	for b.Loop() {
+		m := newMap()
+		for _, k := range keys {
+			m.Set(k, k)
+		}
+	}
+}
+func benchmarkMapLookup[M container.Map[int, int, M]](b *testing.B, m M) {
+	for _, k := range rand.Perm(benchmarkMapSize) {
+		m.Set(k, k)
+	}
+	keys := rand.Perm(benchmarkMapSize)
+	for b.Loop() {
+		for _, k := range keys {
+			m.Get(k)
+		}
+	}
+}
+func benchmarkMapRange[M conformanceMap[M]](b *testing.B, m M) {
+	for _, k := range rand.Perm(benchmarkMapSize) {
+		m.Set(k, k)
+	}
+	for b.Loop() {
+		for lo := 0; lo < benchmarkMapSize; lo += benchmarkMapSize / 64 {
+			for range m.Range(lo, lo+1000, true, false) {
+			}
+		}
+		for range m.All() {
+		}
+	}
+}
+
+func BenchmarkTreeMap插入(b *testing.B)   { benchmarkMapInsert(b, NewTreeMap[int, int]) }
+func BenchmarkBTreeMap插入(b *testing.B)  { benchmarkMapInsert(b, NewBTreeMap[int, int]) }
+func BenchmarkTreeMap查找(b *testing.B)   { benchmarkMapLookup(b, NewTreeMap[int, int]()) }
+func BenchmarkBTreeMap查找(b *testing.B)  { benchmarkMapLookup(b, NewBTreeMap[int, int]()) }
+func BenchmarkTreeMap范围遍历(b *testing.B) { benchmarkMapRange(b, NewTreeMap[int, int]()) }
+func BenchmarkBTreeMap范围遍历(b *testing.B) {
+	benchmarkMapRange(b, NewBTreeMap[int, int]())
+}
@@ -0,0 +1,52 @@
+package gods
+
+import "github.com/viocha/gods/container"
+
+// ===================================接口检查===================================
+
+// 编译期检查所有容器都实现了container包中的接口
+var (
+	_ container.Array[int, Array[int]]                     = Array[int]{}
+	_ container.List[int, *LinkedListNode[int], List[int]] = List[int]{}
+	_ container.String[Str]                                = Str("")
+
+	_ container.Stack[int, Stack[int]] = Stack[int]{}
+	_ container.Queue[int, Queue[int]] = Queue[int]{}
+	_ container.Ring[int, Ring[int]]   = Ring[int]{}
+	_ container.Heap[int, Heap[int]]   = Heap[int]{}
+	_ container.Container              = IndexedHeap[int, int]{}
+	_ container.Container              = MonotonicQueue[int]{}
+	_ container.Container              = MonotonicStack[int]{}
+	_ container.Container              = Fenwick[int]{}
+	_ container.Container              = RangeFenwick[int]{}
+	_ container.Container              = SegmentTree[int, struct{}]{}
+	_ container.Container              = UnionFind[int]{}
+	_ container.Container              = DenseUnionFind{}
+	_ container.Container              = RollbackUnionFind{}
+	_ container.MapContainer[Str, int] = Trie[int]{}
+	_ container.MapContainer[int, int] = AugmentedTreeMap[int, int, int]{}
+	_ container.Container              = IntervalTree[int, int]{}
+	_ container.Container              = RangeSet[int]{}
+
+	_ container.Set[int, HashSet[int]]             = HashSet[int]{}
+	_ container.Set[[]int, CustomHashSet[[]int]]   = CustomHashSet[[]int]{}
+	_ container.LinkedSet[int, LinkedHashSet[int]] = LinkedHashSet[int]{}
+	_ container.TreeSet[int, TreeSet[int]]         = TreeSet[int]{}
+	_ container.TreeSetView[int, TreeSetView[int]] = TreeSetView[int]{}
+	_ container.MultiSet[int, MultiHashSet[int]]   = MultiHashSet[int]{}
+	_ container.MultiSet[int, MultiTreeSet[int]]   = MultiTreeSet[int]{}
+	_ container.Sorted[int]                        = MultiTreeSet[int]{}
+
+	_ container.Map[int, int, HashMap[int, int]]                                  = HashMap[int, int]{}
+	_ container.Map[[]int, int, CustomHashMap[[]int, int]]                        = CustomHashMap[[]int, int]{}
+	_ container.Map[int, int, SyncHashMap[int, int]]                              = SyncHashMap[int, int]{}
+	_ container.Map[int, int, ConcurrentHashMap[int, int]]                        = ConcurrentHashMap[int, int]{}
+	_ container.LinkedMap[int, int, LinkedHashMap[int, int]]                      = LinkedHashMap[int, int]{}
+	_ container.TreeMap[int, int, *MapEntry[int, int], TreeMap[int, int]]         = TreeMap[int, int]{}
+	_ container.TreeMapView[int, int, *MapEntry[int, int], TreeMapView[int, int]] = TreeMapView[int, int]{}
+	_ container.TreeMapContainer[int, int, *MapEntry[int, int]]                   = PersistentTreeMap[int, int]{}
+	_ container.TreeMapContainer[int, int, *MapEntry[int, int]]                   = BTreeMap[int, int]{}
+	_ container.Map[int, int, BTreeMap[int, int]]                                 = BTreeMap[int, int]{}
+	_ container.Mapper[int, int]                                                  = PersistentTreeMap[int, int]{}
+	_ container.TreePrinter                                                       = TreeMap[int, int]{}
+)
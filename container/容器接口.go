@@ -0,0 +1,565 @@
+// 容器接口，gods中的所有容器都实现了对应的接口
+//
+// Go的方法不支持协变返回值，而gods的修改方法会返回容器自身以支持链式调用，因此每类容器有两个接口：
+//   - XxxContainer：只包含不依赖具体容器类型的方法，可以直接作为接口类型使用
+//   - Xxx[..., Self]：额外包含返回容器自身（或以容器自身为参数）的方法，Self为具体容器类型，用作泛型约束
+//
+// 例如，下面的函数可以同时接收HashMap和TreeMap：
+//
+//	func WordCount[M container.Map[string, int, M]](m M, words []string) M {
+//		for _, w := range words {
+//			m.Set(w, m.Get(w)+1)
+//		}
+//		return m
+//	}
+package container
+
+import "iter"
+
+/* ===========================通用接口============================ */
+type Container interface {
+	Len() int
+	String() string
+}
+
+// 清空和复制容器
+type Cloner[Self any] interface {
+	Clear() Self
+	Clone() Self
+}
+
+type TreePrinter interface {
+	PrintTree()
+}
+type Slicer[T any] interface {
+	ToSlice() []T
+}
+type Mapper[K comparable, V any] interface {
+	ToMap() map[K]V
+}
+
+/* ===========================单值容器============================ */
+// 可以转换成slice的容器
+type ValueContainer[T any] interface {
+	Container
+	Slicer[T]
+}
+type Values[T any, Self any] interface {
+	ValueContainer[T]
+	Cloner[Self]
+	ForEach(f func(T)) Self
+}
+
+// --------------------堆--------------------
+type HeapContainer[T any] interface { // 支持自然排序和自定义排序
+	ValueContainer[T]
+	Pop() T
+	Peek() T
+	TryPop() (T, bool) // 堆为空时返回false
+	TryPeek() (T, bool)
+	All() iter.Seq[T] // 按弹出顺序遍历
+}
+type Heap[T any, Self any] interface {
+	HeapContainer[T]
+	Values[T, Self]
+	Reversed() Self // 将堆的大小关系取反，并重新建堆
+	Push(v T) Self
+	Set(i int, v T) Self
+	Del(i int) Self
+	PushTopK(v T, k int) Self // 维持堆大小为k
+}
+
+// --------------------栈--------------------
+type StackContainer[T any] interface {
+	ValueContainer[T]
+	Pop() T
+	Peek() T
+	TryPop() (T, bool) // 栈为空时返回false
+	TryPeek() (T, bool)
+	All() iter.Seq[T]      // 从栈顶到栈底遍历
+	Backward() iter.Seq[T] // 从栈底到栈顶遍历
+}
+type Stack[T any, Self any] interface {
+	StackContainer[T]
+	Values[T, Self]
+	Push(v T) Self
+	PopUntil(f func(T) bool) Self // 单调栈操作
+}
+
+// --------------------队列--------------------
+type QueueContainer[T any] interface {
+	ValueContainer[T]
+	// 单向队列基本操作
+	Pop() T
+	Peek() T
+	// 双向队列基本操作
+	PeekFront() T
+	PeekBack() T
+	PopFront() T
+	PopBack() T
+	// 队列为空时返回false
+	TryPop() (T, bool)
+	TryPeek() (T, bool)
+	TryPeekFront() (T, bool)
+	TryPeekBack() (T, bool)
+	TryPopFront() (T, bool)
+	TryPopBack() (T, bool)
+	// 随机访问(支持负数索引)
+	Get(i int) T
+	TryGet(i int) (T, bool)
+	Cap() int
+	// 迭代器
+	All() iter.Seq2[int, T]
+	Values() iter.Seq[T]
+	Backward() iter.Seq2[int, T]
+}
+type Queue[T any, Self any] interface {
+	QueueContainer[T]
+	Values[T, Self]
+	Push(v T) Self
+	PushFront(v T) Self
+	PushBack(v T) Self
+	// 单调队列操作
+	PopUntil(f func(T) bool) Self
+	PopFrontUntil(f func(T) bool) Self
+	PopBackUntil(f func(T) bool) Self
+	// 随机访问和容量
+	Set(i int, v T) Self
+	Rotate(n int) Self // 将队尾的n个元素移到队首
+	Reserve(n int) Self
+}
+
+// --------------------环形缓冲区--------------------
+type RingContainer[T any] interface {
+	ValueContainer[T]
+	Cap() int
+	IsFull() bool
+
+	// 基本操作(支持负数索引)
+	Get(i int) T
+	Front() T
+	Back() T
+	Pop() T
+	Peek() T
+	PopFront() T
+	PopBack() T
+
+	// 不会panic的操作
+	TryPushBack(v T) bool // 满时拒绝插入则返回false
+	TryPop() (T, bool)    // 为空时返回false
+	TryPeek() (T, bool)
+	TryPopFront() (T, bool)
+	TryPopBack() (T, bool)
+
+	// 迭代器
+	All() iter.Seq2[int, T]
+	Values() iter.Seq[T]
+	Backward() iter.Seq2[int, T]
+}
+type Ring[T any, Self any] interface {
+	RingContainer[T]
+	Values[T, Self]
+	Set(i int, v T) Self
+	Push(v T) Self
+	PushBack(v T) Self
+}
+
+// --------------------动态数组--------------------
+type ArrayContainer[T any] interface {
+	ValueContainer[T]
+
+	// 基本操作(支持负数索引)
+	Get(i int) T
+	Pop() T                 // 从尾部删除
+	Choice() T              // 随机选择一个元素
+	TryGet(i int) (T, bool) // 索引超出范围时返回false
+	TryPop() (T, bool)
+
+	// 查找
+	Has(x T) bool
+	HasFunc(eq func(T) bool) bool
+	Index(x T) int
+	IndexFunc(eq func(T) bool) int       // 线性查找满足条件的索引
+	BiSearch(x T) int                    // 二分查找>=x的位置
+	BiSearchFunc(check func(T) bool) int // 二分查找第一个满足条件的位置
+	Find(check func(T) bool) T
+
+	// 聚合操作
+	Min() T
+	MinFunc(f func(T, T) int) T
+	Max() T
+	MaxFunc(f func(T, T) int) T
+	Sum() T
+	Any() bool
+	Every() bool
+	Join(seq string) string
+	Reduce(f func(T, T) T) T
+	ReduceInitial(f func(T, T) T, initial T) T
+
+	// 迭代器
+	All() iter.Seq2[int, T]
+	Keys() iter.Seq[int]
+	Values() iter.Seq[T]
+	Backward() iter.Seq2[int, T]
+}
+type Array[T any, Self any] interface {
+	ArrayContainer[T]
+	Values[T, Self]
+	ForEachIdx(f func(int)) Self
+	ForEachIdxVal(f func(int, T)) Self
+
+	// 基本操作(支持负数索引)
+	Set(i int, v T) Self
+	Ins(i int, v T) Self
+	Del(i int) Self
+	Push(v ...T) Self
+	Slice(i, j int) Self  // 修改成切片内容
+	Sliced(i, j int) Self // 返回新的切片视图，不修改内部数组
+
+	// 组合操作
+	Replace(i, j int, v ...T) Self
+	ReplaceFunc(f func(int, T) T) Self
+	DelFunc(f func(int, T) bool) Self
+	Extend(other Self) Self
+	Fill(x T) Self
+	FillFunc(f func(int) T) Self
+	Reverse() Self
+	Reversed() Self
+	Shuffle() Self
+
+	// 子数组查找、排序、比较
+	HasSubArr(sub Self) bool
+	IndexSubArr(sub Self) int
+	Sort() Self
+	SortFunc(cmp func(T, T) int) Self
+	Cmp(other Self) int
+	Equal(other Self) bool
+
+	// 函数式操作(非原地)
+	Map(f func(T) T) Self
+	Filter(f func(T) bool) Self
+}
+
+// --------------------双向链表--------------------
+// Node为链表节点类型
+type ListContainer[T any, Node any] interface {
+	ValueContainer[T]
+
+	// 基本操作
+	Front() Node
+	Back() Node
+	PushFront(v T) Node // 返回新建的节点
+	PushBack(v T) Node
+	InsAfter(v T, mark Node) Node
+	InsBefore(v T, mark Node) Node
+	PopFront() Node
+	PopBack() Node
+	Remove(node Node) T // 删除后返回节点值
+
+	// 移动节点
+	MoveBefore(node, mark Node) T
+	MoveAfter(node, mark Node) T
+	MoveToFront(node Node) T
+	MoveToBack(node Node) T
+
+	// 迭代器
+	All() iter.Seq2[int, T]
+	Values() iter.Seq[T]
+	Backward() iter.Seq2[int, T]
+}
+type List[T any, Node any, Self any] interface {
+	ListContainer[T, Node]
+	Values[T, Self]
+}
+
+// --------------------字符串--------------------
+type String[Self any] interface {
+	Len() int // 字符个数
+	LenBytes() int
+	S() string
+	ToRunes() []rune
+	Get(i int) rune
+
+	// 查找和比较
+	Has(substr Self) bool
+	Index(substr Self) int
+	Count(substr Self) int
+	StartsWith(prefix Self) bool
+	EndsWith(suffix Self) bool
+	Cmp(other Self) int
+	EqualFold(other Self) bool
+
+	// 转换
+	Slice(start, end int) Self
+	Split(sep Self) []Self
+	Replace(oldStr, newStr Self) Self
+	Map(f func(rune) rune) Self
+	Reverse() Self
+	ToLower() Self
+	ToUpper() Self
+	TrimSpace() Self
+}
+
+// --------------------集合--------------------
+type SetContainer[T any] interface {
+	ValueContainer[T]
+	Has(v T) bool
+}
+type Set[T any, Self any] interface {
+	SetContainer[T]
+	Values[T, Self]
+
+	// 集合基本操作
+	Add(v T) Self
+	Del(v T) Self
+
+	// 组合操作
+	DelFunc(f func(T) bool) Self
+	HasSubset(other Self) bool
+	Union(other Self) Self
+	Intersect(other Self) Self
+	Difference(other Self) Self
+}
+
+// 按顺序排列的元素，二分查找可能panic，需要确保存在
+type Sorted[T any] interface {
+	First() T
+	Last() T
+	Lower(x T) T
+	Higher(x T) T
+	Floor(x T) T
+	Ceiling(x T) T
+
+	// 排名相关的查找
+	Rank(x T) int
+	Select(i int) T
+
+	// 不存在时返回false
+	TryFirst() (T, bool)
+	TryLast() (T, bool)
+	TryLower(x T) (T, bool)
+	TryHigher(x T) (T, bool)
+	TryFloor(x T) (T, bool)
+	TryCeiling(x T) (T, bool)
+	TrySelect(i int) (T, bool)
+}
+
+// 有序集合
+type TreeSetContainer[T any] interface {
+	SetContainer[T]
+	Sorted[T]
+
+	// 迭代器
+	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+	AscendFrom(x T) iter.Seq[T]
+	DescendFrom(x T) iter.Seq[T]
+	Range(lo, hi T, loInclusive, hiInclusive bool) iter.Seq[T]
+}
+type TreeSet[T any, Self any] interface {
+	TreeSetContainer[T]
+	Set[T, Self]
+
+	// 按元素的范围操作，由loInclusive和hiInclusive决定是否包含边界
+	// 截取子集合的HeadSet、TailSet和SubSet返回视图，参见TreeSetView
+	CountRange(lo, hi T, loInclusive, hiInclusive bool) int
+	DelRange(lo, hi T, loInclusive, hiInclusive bool) Self
+
+	// 对称差
+	SymmetricDifference(other Self) Self
+
+	// 拆分与合并
+	Split(x T) Self       // o保留小于x的部分，返回大于等于x的部分
+	Join(other Self) Self // 要求o的元素都小于other的元素
+}
+
+// 有序集合的视图，只包含范围内的元素，不复制节点，修改会作用于原集合
+type TreeSetViewContainer[T any] interface {
+	SetContainer[T]
+	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+	TryFirst() (T, bool) // 视图为空时返回false
+	TryLast() (T, bool)
+
+	// 范围与视图的范围取交集
+	Range(lo, hi T, loInclusive, hiInclusive bool) iter.Seq[T]
+	CountRange(lo, hi T, loInclusive, hiInclusive bool) int
+}
+type TreeSetView[T any, Self any] interface {
+	TreeSetViewContainer[T]
+	Clear() Self // 从原集合中删除范围内的元素
+	Add(v T) Self
+	Del(v T) Self
+
+	// 截取更小的视图
+	HeadSet(x T) Self // 小于等于x的元素
+	TailSet(x T) Self // 大于等于x的元素
+	SubSet(lo, hi T, loInclusive, hiInclusive bool) Self
+}
+
+// 链式集合
+type LinkedSetContainer[T any] interface {
+	SetContainer[T]
+
+	// 链式操作
+	First() T
+	Last() T
+	Prev(v T) T
+	Next(v T) T
+	TryFirst() (T, bool) // 不存在时返回false
+	TryLast() (T, bool)
+	TryPrev(v T) (T, bool)
+	TryNext(v T) (T, bool)
+
+	// 迭代器
+	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+}
+type LinkedSet[T any, Self any] interface {
+	LinkedSetContainer[T]
+	Set[T, Self]
+}
+
+// 多重集
+type MultiSetContainer[T comparable] interface {
+	SetContainer[T]
+	Mapper[T, int] // ToMap方法
+
+	Total() int    // 所有重数之和
+	Count(x T) int // 元素的重数
+
+	// 迭代器
+	All() iter.Seq2[T, int] // 遍历元素及其重数
+	Values() iter.Seq[T]    // 按重数重复遍历元素
+}
+type MultiSet[T comparable, Self any] interface {
+	MultiSetContainer[T]
+	Set[T, Self]
+
+	// 计数操作
+	ForEachCnt(f func(T, int)) Self      // 遍历元素及其重数
+	AddN(x T, n int) Self                // AddN忽略小于等于0的数
+	DelN(x T, n int) Self                // DelN忽略小于等于0的数
+	DelAll(x T) Self                     // 删除元素的所有重数
+	DelAllFunc(f func(T, int) bool) Self // 删除满足条件的元素的所有重数
+	ReplaceFunc(f func(T, int) int) Self // 替换元素的重数
+}
+
+/* ===========================键值对容器============================ */
+
+// 通用映射
+type MapContainer[K any, V any] interface {
+	Container
+
+	// 基本操作
+	Get(k K) V // 获取键对应的值，对于不存在的key，若factory属性不为空，则使用factory函数设置值，否则返回类型零值(不会报错)
+	Has(k K) bool
+	GetOr(k K, v V) V
+	Lookup(k K) (V, bool) // 不存在时返回false，不会调用factory函数
+
+	// 迭代器和键值列表
+	All() iter.Seq2[K, V]
+	Keys() iter.Seq[K]
+	Values() iter.Seq[V]
+	KeySlice() []K
+	ValueSlice() []V
+}
+type Map[K any, V any, Self any] interface {
+	MapContainer[K, V]
+	Cloner[Self]
+	ForEach(f func(K, V)) Self
+
+	// 基本操作
+	Set(k K, v V) Self
+	Del(k K) Self
+
+	// 组合操作
+	GetOrSet(k K, v V) V
+	Extend(other Self) Self
+	DelFunc(f func(K, V) bool) Self
+	ReplaceFunc(f func(K, V) V) Self // 替换键值对的值
+}
+
+// 有序映射，Entry为键值对类型，二分查找不存在时返回nil
+type TreeMapContainer[K any, V any, Entry any] interface {
+	MapContainer[K, V]
+
+	// 二分查找
+	First() Entry
+	Last() Entry
+	Lower(k K) Entry
+	Higher(k K) Entry
+	Floor(k K) Entry
+	Ceiling(k K) Entry
+
+	// 排名相关的查找
+	Rank(k K) int
+	Select(i int) Entry
+
+	// 迭代器
+	Backward() iter.Seq2[K, V]
+	AscendFrom(k K) iter.Seq2[K, V]
+	DescendFrom(k K) iter.Seq2[K, V]
+	Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V]
+}
+type TreeMap[K any, V any, Entry any, Self any] interface {
+	TreeMapContainer[K, V, Entry]
+	Map[K, V, Self]
+
+	// 截取子映射
+	HeadMap(n int) Self
+	TailMap(n int) Self
+
+	// 按键的范围操作，由loInclusive和hiInclusive决定是否包含边界
+	// 截取子映射的SubMap、HeadMapByKey和TailMapByKey返回视图，参见TreeMapView
+	CountRange(lo, hi K, loInclusive, hiInclusive bool) int
+	DelRange(lo, hi K, loInclusive, hiInclusive bool) Self
+
+	// 拆分与合并
+	Split(k K) Self       // o保留小于k的部分，返回大于等于k的部分
+	Join(other Self) Self // 要求o的键都小于other的键
+}
+
+// 有序映射的视图，只包含范围内的键，不复制节点，修改会作用于原映射，Entry为键值对类型
+type TreeMapViewContainer[K any, V any, Entry any] interface {
+	MapContainer[K, V]
+	First() Entry // 视图为空时返回nil
+	Last() Entry
+	Backward() iter.Seq2[K, V]
+
+	// 范围与视图的范围取交集
+	Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V]
+	CountRange(lo, hi K, loInclusive, hiInclusive bool) int
+}
+type TreeMapView[K any, V any, Entry any, Self any] interface {
+	TreeMapViewContainer[K, V, Entry]
+	Clear() Self       // 从原映射中删除范围内的键
+	Set(k K, v V) Self // 键超出范围时会panic
+	Del(k K) Self
+
+	// 截取更小的视图
+	SubMap(lo, hi K, loInclusive, hiInclusive bool) Self
+	HeadMapByKey(hi K, inclusive bool) Self
+	TailMapByKey(lo K, inclusive bool) Self
+}
+
+// 能保存插入顺序的映射
+type LinkedMapContainer[K any, V any] interface {
+	MapContainer[K, V]
+
+	// 链式操作
+	FirstKey() K
+	LastKey() K
+	NextKey(k K) K
+	PrevKey(k K) K
+	TryFirstKey() (K, bool) // 不存在时返回false
+	TryLastKey() (K, bool)
+	TryNextKey(k K) (K, bool)
+	TryPrevKey(k K) (K, bool)
+
+	Backward() iter.Seq2[K, V]
+}
+type LinkedMap[K any, V any, Self any] interface {
+	LinkedMapContainer[K, V]
+	Map[K, V, Self]
+}
@@ -0,0 +1,44 @@
+package gods
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/viocha/gods/container"
+)
+
+func wordCount[M container.Map[string, int, M]](m M, words []string) M {
+	for _, w := range words {
+		m.Set(w, m.Get(w)+1)
+	}
+	return m
+}
+
+func sortedSum[S container.Sorted[int]](s S, k int) (sum int) {
+	for i := 1; i <= k; i++ {
+		sum += s.Select(i)
+	}
+	return
+}
+
+func Test容器接口(t *testing.T) {
+	words := []string{"b", "a", "c", "a", "b", "a"}
+	hm := wordCount(NewHashMap[string, int](), words)
+	tm := wordCount(NewTreeMap[string, int](), words)
+	lm := wordCount(NewLinkedHashMap[string, int](), words)
+	fmt.Println(hm, tm, lm)
+	if !maps.Equal(hm.ToMap(), tm.ToMap()) || !maps.Equal(hm.ToMap(), lm.ToMap()) {
+		t.Errorf("不同映射的结果不同")
+	}
+
+	var m container.MapContainer[string, int] = tm
+	fmt.Println("作为接口类型使用：", m.Len(), m.KeySlice())
+
+	ts := NewTreeSet[int]().Add(5).Add(1).Add(3)
+	ms := NewMultiTreeSet[int]().AddN(1, 2).Add(5)
+	fmt.Println("最小的2个元素之和：", sortedSum(ts, 2), sortedSum(ms, 2))
+	if sortedSum(ts, 2) != 4 || sortedSum(ms, 2) != 2 {
+		t.Errorf("Select错误")
+	}
+}
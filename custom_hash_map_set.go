@@ -0,0 +1,317 @@
+package gods
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ===================================哈希函数===================================
+
+// 自定义的哈希函数和相等函数，Equal返回true的两个键必须具有相同的Hash
+type Hasher[K any] struct {
+	Hash  func(K) uint64
+	Equal func(K, K) bool
+}
+
+// 可比较类型的哈希函数
+func ComparableHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return Hasher[K]{
+		Hash:  func(k K) uint64 { return maphash.Comparable(seed, k) },
+		Equal: func(a, b K) bool { return a == b },
+	}
+}
+
+// 按元素内容哈希的切片哈希函数
+func SliceHasher[T comparable]() Hasher[[]T] {
+	seed := maphash.MakeSeed()
+	return Hasher[[]T]{
+		Hash: func(s []T) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			for _, x := range s {
+				maphash.WriteComparable(&h, x)
+			}
+			return h.Sum64()
+		},
+		Equal: slices.Equal[[]T],
+	}
+}
+
+// 按元素内容哈希的数组哈希函数
+func ArrayHasher[T comparable]() Hasher[Array[T]] {
+	h := SliceHasher[T]()
+	return Hasher[Array[T]]{
+		Hash:  func(a Array[T]) uint64 { return h.Hash(a.GetSlice()) },
+		Equal: func(a, b Array[T]) bool { return h.Equal(a.GetSlice(), b.GetSlice()) },
+	}
+}
+
+// 不区分大小写的字符串哈希函数，和Str.EqualFold的语义一致
+func StrFoldHasher() Hasher[Str] {
+	seed := maphash.MakeSeed()
+	return Hasher[Str]{
+		Hash: func(s Str) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			buf := make([]byte, 0, len(s))
+			for _, r := range s {
+				buf = utf8.AppendRune(buf, foldRune(r))
+			}
+			h.Write(buf)
+			return h.Sum64()
+		},
+		Equal: Str.EqualFold,
+	}
+}
+
+// 返回大小写等价类中最小的rune，作为统一的代表
+func foldRune(r rune) rune {
+	res := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		res = min(res, f)
+	}
+	return res
+}
+
+// ===================================自定义哈希表===================================
+
+type hashEntry[K, V any] struct {
+	k K
+	v V
+}
+
+// 使用Hasher计算哈希和判断相等的哈希表，键不要求是comparable
+type CustomHashMap[K, V any] struct {
+	m       map[uint64][]hashEntry[K, V] // 哈希值相同的键值对组成一个桶
+	h       Hasher[K]
+	size    *int // 键值对个数，仅保存引用，以便值传递进行修改
+	factory func() V
+}
+
+func NewCustomHashMap[K, V any](h Hasher[K]) CustomHashMap[K, V] {
+	return CustomHashMap[K, V]{m: map[uint64][]hashEntry[K, V]{}, h: h, size: new(int)}
+}
+func (o CustomHashMap[K, V]) WithFactory(factory func() V) CustomHashMap[K, V] {
+	o.factory = factory
+	return o
+}
+
+// 辅助函数，返回键所在的桶，以及键在桶中的位置，不存在时为-1
+func (o CustomHashMap[K, V]) find(k K) (hash uint64, i int) {
+	hash = o.h.Hash(k)
+	i = slices.IndexFunc(o.m[hash], func(e hashEntry[K, V]) bool { return o.h.Equal(e.k, k) })
+	return hash, i
+}
+
+// --------------------Container接口--------------------
+
+func (o CustomHashMap[K, V]) Len() int { return *o.size }
+func (o CustomHashMap[K, V]) Clear() CustomHashMap[K, V] {
+	clear(o.m)
+	*o.size = 0
+	return o
+}
+func (o CustomHashMap[K, V]) Clone() CustomHashMap[K, V] {
+	res := NewCustomHashMap[K, V](o.h).WithFactory(o.factory)
+	for hash, bucket := range o.m {
+		res.m[hash] = slices.Clone(bucket)
+	}
+	*res.size = *o.size
+	return res
+}
+func (o CustomHashMap[K, V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "CustomHashMap[" + strings.Join(entries, " ") + "]"
+}
+
+// --------------------Map接口--------------------
+// 遍历和转换
+func (o CustomHashMap[K, V]) ForEach(f func(K, V)) CustomHashMap[K, V] {
+	for k, v := range o.All() {
+		f(k, v)
+	}
+	return o
+}
+
+// 迭代器，顺序不确定，遍历时可以删除键
+func (o CustomHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, bucket := range o.m {
+			for _, e := range bucket {
+				if !yield(e.k, e.v) {
+					return
+				}
+			}
+		}
+	}
+}
+func (o CustomHashMap[K, V]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o CustomHashMap[K, V]) Values() iter.Seq[V] { return seqValues(o.All()) }
+
+// 基本操作
+func (o CustomHashMap[K, V]) Has(k K) bool { _, i := o.find(k); return i >= 0 }
+func (o CustomHashMap[K, V]) Set(k K, v V) CustomHashMap[K, V] {
+	hash, i := o.find(k)
+	if i >= 0 {
+		o.m[hash][i].v = v
+		return o
+	}
+	o.m[hash] = append(o.m[hash], hashEntry[K, V]{k, v})
+	*o.size++
+	return o
+}
+func (o CustomHashMap[K, V]) Del(k K) CustomHashMap[K, V] {
+	hash, i := o.find(k)
+	if i < 0 {
+		return o
+	}
+	bucket := o.m[hash]
+	if len(bucket) == 1 {
+		delete(o.m, hash)
+	} else { // 创建新的桶，不影响正在遍历的旧桶
+		o.m[hash] = append(bucket[:i:i], bucket[i+1:]...)
+	}
+	*o.size--
+	return o
+}
+func (o CustomHashMap[K, V]) Get(k K) V {
+	hash, i := o.find(k)
+	if i >= 0 {
+		return o.m[hash][i].v
+	}
+	if o.factory != nil {
+		v := o.factory()
+		o.Set(k, v)
+		return v
+	}
+	return *new(V)
+}
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o CustomHashMap[K, V]) Lookup(k K) (V, bool) {
+	if hash, i := o.find(k); i >= 0 {
+		return o.m[hash][i].v, true
+	}
+	return *new(V), false
+}
+
+// 组合操作
+func (o CustomHashMap[K, V]) GetOr(k K, v V) V {
+	hash, i := o.find(k)
+	if i < 0 {
+		return v
+	}
+	return o.m[hash][i].v
+}
+func (o CustomHashMap[K, V]) GetOrSet(k K, v V) V {
+	hash, i := o.find(k)
+	if i < 0 {
+		o.Set(k, v)
+		return v
+	}
+	return o.m[hash][i].v
+}
+func (o CustomHashMap[K, V]) Extend(other CustomHashMap[K, V]) CustomHashMap[K, V] {
+	other.ForEach(func(k K, v V) { o.Set(k, v) })
+	return o
+}
+func (o CustomHashMap[K, V]) DelFunc(f func(K, V) bool) CustomHashMap[K, V] {
+	return o.ForEach(func(k K, v V) {
+		if f(k, v) {
+			o.Del(k)
+		}
+	})
+}
+func (o CustomHashMap[K, V]) ReplaceFunc(f func(K, V) V) CustomHashMap[K, V] {
+	return o.ForEach(func(k K, v V) { o.Set(k, f(k, v)) })
+}
+
+// 键值列表查询
+func (o CustomHashMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o CustomHashMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
+
+// ===================================自定义哈希集===================================
+type CustomHashSet[T any] struct {
+	m CustomHashMap[T, struct{}]
+}
+
+func NewCustomHashSet[T any](h Hasher[T]) CustomHashSet[T] {
+	return CustomHashSet[T]{m: NewCustomHashMap[T, struct{}](h)}
+}
+func NewCustomHashSetFromSlice[T any](h Hasher[T], s []T) CustomHashSet[T] {
+	res := NewCustomHashSet(h)
+	for _, v := range s {
+		res.Add(v)
+	}
+	return res
+}
+
+// --------------------ValueContainer接口--------------------
+func (o CustomHashSet[T]) Len() int                { return o.m.Len() }
+func (o CustomHashSet[T]) Clear() CustomHashSet[T] { o.m.Clear(); return o }
+func (o CustomHashSet[T]) Clone() CustomHashSet[T] { o.m = o.m.Clone(); return o }
+func (o CustomHashSet[T]) String() string          { return "CustomHashSet" + fmt.Sprint(o.ToSlice()) }
+func (o CustomHashSet[T]) ToSlice() []T            { return o.m.KeySlice() }
+func (o CustomHashSet[T]) ForEach(f func(T)) CustomHashSet[T] {
+	o.m.ForEach(func(k T, v struct{}) { f(k) })
+	return o
+}
+
+// 迭代器，顺序不确定
+func (o CustomHashSet[T]) All() iter.Seq[T] { return o.m.Keys() }
+
+// --------------------Set接口--------------------
+
+// 基本操作
+func (o CustomHashSet[T]) Has(v T) bool             { return o.m.Has(v) }
+func (o CustomHashSet[T]) Del(v T) CustomHashSet[T] { o.m.Del(v); return o }
+func (o CustomHashSet[T]) Add(v T) CustomHashSet[T] { o.m.Set(v, struct{}{}); return o }
+
+// 组合操作
+func (o CustomHashSet[T]) HasSubset(other CustomHashSet[T]) bool {
+	for v := range other.All() {
+		if !o.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+func (o CustomHashSet[T]) DelFunc(f func(T) bool) CustomHashSet[T] {
+	return o.ForEach(func(v T) {
+		if f(v) {
+			o.Del(v)
+		}
+	})
+}
+func (o CustomHashSet[T]) Union(other CustomHashSet[T]) CustomHashSet[T] {
+	res := o.Clone()
+	other.ForEach(func(v T) { res.Add(v) })
+	return res
+}
+func (o CustomHashSet[T]) Intersect(other CustomHashSet[T]) CustomHashSet[T] {
+	res := NewCustomHashSet(o.m.h)
+	o.ForEach(func(v T) {
+		if other.Has(v) {
+			res.Add(v)
+		}
+	})
+	return res
+}
+func (o CustomHashSet[T]) Difference(other CustomHashSet[T]) CustomHashSet[T] {
+	res := NewCustomHashSet(o.m.h)
+	o.ForEach(func(v T) {
+		if !other.Has(v) {
+			res.Add(v)
+		}
+	})
+	return res
+}
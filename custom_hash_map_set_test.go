@@ -0,0 +1,35 @@
+package gods
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test自定义哈希表(t *testing.T) {
+	memo := NewCustomHashMap[[]int, int](SliceHasher[int]())
+	memo.Set([]int{1, 2, 3}, 6).Set([]int{3, 2, 1}, 6).Set([]int{1, 2, 3}, 7)
+	if memo.Len() != 2 || memo.Get([]int{1, 2, 3}) != 7 {
+		t.Errorf("切片为键的哈希表：%v", memo)
+	}
+	fmt.Println(memo)
+	memo.Del([]int{3, 2, 1})
+	fmt.Println("删除之后：", memo, memo.Len())
+
+	states := NewCustomHashMap[Array[int], string](ArrayHasher[int]()).WithFactory(func() string { return "new" })
+	fmt.Println(states.Get(ValArr(1, 2)), states.Has(ValArr(1, 2)), states.Has(ValArr(2, 1)))
+
+	words := NewCustomHashSetFromSlice(StrFoldHasher(), []Str{"Go", "GO", "go", "Rust", "ſ", "S"})
+	if words.Len() != 3 || !words.Has("rUST") {
+		t.Errorf("不区分大小写的集合：%v", words)
+	}
+	fmt.Println(words)
+
+	m := NewCustomHashMap[int, int](ComparableHasher[int]())
+	for i := range 100 {
+		m.Set(i, i)
+	}
+	m.DelFunc(func(k, v int) bool { return k%2 == 0 })
+	if m.Len() != 50 || m.Has(10) || !m.Has(11) {
+		t.Errorf("DelFunc之后：%v", m.Len())
+	}
+}
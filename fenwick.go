@@ -0,0 +1,236 @@
+package gods
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ===================================树状数组===================================
+
+// 树状数组，支持O(logn)的单点修改和前缀和查询，索引从0开始，区间都是左闭右开
+type Fenwick[T Number] struct {
+	tree *[]T // 下标从1开始，tree[i]保存区间(i-lowbit(i), i]的和
+}
+
+// 创建长度为n，元素都为0的树状数组
+func NewFenwick[T Number](n int) Fenwick[T] {
+	if n < 0 {
+		panic("长度不能为负数：" + strconv.Itoa(n))
+	}
+	tree := make([]T, n+1)
+	return Fenwick[T]{tree: &tree}
+}
+
+// 从数组创建，O(n)
+func NewFenwickFromArray[T Number](arr Array[T]) Fenwick[T] {
+	tree := make([]T, arr.Len()+1)
+	copy(tree[1:], arr.GetSlice())
+	buildFenwick(tree)
+	return Fenwick[T]{tree: &tree}
+}
+
+// --------------------辅助函数--------------------
+func lowbit(i int) int { return i & -i }
+
+// 将原始值原地转换为树状数组，每个节点只向父节点累加一次
+func buildFenwick[T Number](tree []T) {
+	for i := 1; i < len(tree); i++ {
+		if j := i + lowbit(i); j < len(tree) {
+			tree[j] += tree[i]
+		}
+	}
+}
+
+func (o Fenwick[T]) checkIdx(i int) {
+	if i < 0 || i >= o.Len() {
+		panic("索引超出范围：" + strconv.Itoa(i))
+	}
+}
+func (o Fenwick[T]) checkRange(l, r int) {
+	if l < 0 || r > o.Len() || l > r {
+		panic(fmt.Sprintf("区间超出范围：[%d, %d)", l, r))
+	}
+}
+
+// 内部实现，i为从1开始的下标
+func (o Fenwick[T]) add(i int, delta T) {
+	tree := *o.tree
+	for ; i < len(tree); i += lowbit(i) {
+		tree[i] += delta
+	}
+}
+func (o Fenwick[T]) prefix(i int) (sum T) {
+	tree := *o.tree
+	for ; i > 0; i -= lowbit(i) {
+		sum += tree[i]
+	}
+	return sum
+}
+
+// --------------------Container接口--------------------
+func (o Fenwick[T]) Len() int       { return len(*o.tree) - 1 }
+func (o Fenwick[T]) String() string { return "Fenwick" + fmt.Sprint(o.ToSlice()) }
+func (o Fenwick[T]) Clear() Fenwick[T] {
+	clear(*o.tree)
+	return o
+}
+func (o Fenwick[T]) Clone() Fenwick[T] {
+	tree := append([]T{}, *o.tree...)
+	o.tree = &tree
+	return o
+}
+
+// 还原每个位置的值，O(n)
+func (o Fenwick[T]) ToSlice() []T {
+	tree := append([]T{}, *o.tree...)
+	for i := len(tree) - 1; i > 0; i-- { // 按构建的逆序撤销累加
+		if j := i + lowbit(i); j < len(tree) {
+			tree[j] -= tree[i]
+		}
+	}
+	return tree[1:]
+}
+
+// --------------------基本操作--------------------
+
+// 将第i个元素加上delta
+func (o Fenwick[T]) Add(i int, delta T) Fenwick[T] {
+	o.checkIdx(i)
+	o.add(i+1, delta)
+	return o
+}
+
+// 将第i个元素设置为x
+func (o Fenwick[T]) Set(i int, x T) Fenwick[T] { return o.Add(i, x-o.Get(i)) }
+
+// 第i个元素的值
+func (o Fenwick[T]) Get(i int) T { return o.RangeSum(i, i+1) }
+
+// 前i个元素的和，即区间[0, i)的和
+func (o Fenwick[T]) Prefix(i int) T {
+	o.checkRange(0, i)
+	return o.prefix(i)
+}
+
+// 区间[l, r)的和
+func (o Fenwick[T]) RangeSum(l, r int) T {
+	o.checkRange(l, r)
+	return o.prefix(r) - o.prefix(l)
+}
+
+// 所有元素的和
+func (o Fenwick[T]) Total() T { return o.prefix(o.Len()) }
+
+// 最小的索引i，使得区间[0, i]的和大于等于target，不存在时返回Len()
+// 要求所有元素非负，O(logn)
+func (o Fenwick[T]) LowerBound(target T) int {
+	tree := *o.tree
+	n, pos := o.Len(), 0
+	step := 1
+	for step*2 <= n {
+		step *= 2
+	}
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next <= n && tree[next] < target {
+			pos = next
+			target -= tree[next]
+		}
+	}
+	return pos
+}
+
+// ===================================区间修改树状数组===================================
+
+// 支持O(logn)的区间修改和区间求和的树状数组，索引从0开始，区间都是左闭右开
+// 内部维护差分数组d和d[i]*i的两个树状数组
+type RangeFenwick[T Number] struct {
+	d, di Fenwick[T]
+}
+
+// 创建长度为n，元素都为0的树状数组
+func NewRangeFenwick[T Number](n int) RangeFenwick[T] {
+	return RangeFenwick[T]{d: NewFenwick[T](n), di: NewFenwick[T](n)}
+}
+
+// 从数组创建，O(n)
+func NewRangeFenwickFromArray[T Number](arr Array[T]) RangeFenwick[T] {
+	n := arr.Len()
+	d, di := make([]T, n+1), make([]T, n+1)
+	var pre T
+	for i, x := range arr.GetSlice() {
+		d[i+1] = x - pre
+		di[i+1] = d[i+1] * T(i+1)
+		pre = x
+	}
+	buildFenwick(d)
+	buildFenwick(di)
+	return RangeFenwick[T]{d: Fenwick[T]{tree: &d}, di: Fenwick[T]{tree: &di}}
+}
+
+// 在差分数组的第i个位置（从1开始）加上delta
+func (o RangeFenwick[T]) addDiff(i int, delta T) {
+	if i <= o.Len() {
+		o.d.add(i, delta)
+		o.di.add(i, delta*T(i))
+	}
+}
+
+// --------------------Container接口--------------------
+func (o RangeFenwick[T]) Len() int       { return o.d.Len() }
+func (o RangeFenwick[T]) String() string { return "RangeFenwick" + fmt.Sprint(o.ToSlice()) }
+func (o RangeFenwick[T]) Clear() RangeFenwick[T] {
+	o.d.Clear()
+	o.di.Clear()
+	return o
+}
+func (o RangeFenwick[T]) Clone() RangeFenwick[T] {
+	return RangeFenwick[T]{d: o.d.Clone(), di: o.di.Clone()}
+}
+
+// 还原每个位置的值，O(n)
+func (o RangeFenwick[T]) ToSlice() []T {
+	res := o.d.ToSlice()
+	for i := 1; i < len(res); i++ { // 差分数组的前缀和
+		res[i] += res[i-1]
+	}
+	return res
+}
+
+// --------------------基本操作--------------------
+
+// 将区间[l, r)的每个元素加上delta
+func (o RangeFenwick[T]) AddRange(l, r int, delta T) RangeFenwick[T] {
+	o.d.checkRange(l, r)
+	if l < r {
+		o.addDiff(l+1, delta)
+		o.addDiff(r+1, -delta)
+	}
+	return o
+}
+
+// 将第i个元素加上delta
+func (o RangeFenwick[T]) Add(i int, delta T) RangeFenwick[T] {
+	o.d.checkIdx(i)
+	return o.AddRange(i, i+1, delta)
+}
+
+// 第i个元素的值
+func (o RangeFenwick[T]) Get(i int) T {
+	o.d.checkIdx(i)
+	return o.d.prefix(i + 1)
+}
+
+// 前i个元素的和，即区间[0, i)的和
+func (o RangeFenwick[T]) Prefix(i int) T {
+	o.d.checkRange(0, i)
+	return T(i+1)*o.d.prefix(i) - o.di.prefix(i)
+}
+
+// 区间[l, r)的和
+func (o RangeFenwick[T]) RangeSum(l, r int) T {
+	o.d.checkRange(l, r)
+	return o.Prefix(r) - o.Prefix(l)
+}
+
+// 所有元素的和
+func (o RangeFenwick[T]) Total() T { return o.Prefix(o.Len()) }
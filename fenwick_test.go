@@ -0,0 +1,104 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test树状数组(t *testing.T) {
+	f := NewFenwickFromArray(NewArrayFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6}))
+	fmt.Println(f, "总和：", f.Total(), "前3个的和：", f.Prefix(3), "[2,5)的和：", f.RangeSum(2, 5))
+	if f.Prefix(3) != 8 || f.RangeSum(2, 5) != 10 || f.Total() != 31 || f.Get(5) != 9 {
+		t.Errorf("求和错误")
+	}
+	// 前缀和依次为3 4 8 9 14 23 25 31
+	for target, want := range map[int]int{0: 0, 3: 0, 4: 1, 5: 2, 14: 4, 15: 5, 31: 7, 32: 8} {
+		if got := f.LowerBound(target); got != want {
+			t.Errorf("LowerBound(%d)=%d，期望%d", target, got, want)
+		}
+	}
+
+	// 与暴力结果比较
+	n := 50
+	arr := make([]int, n)
+	f = NewFenwick[int](n)
+	for range 2000 {
+		i, x := rand.Intn(n), rand.Intn(20)
+		if rand.Intn(2) == 0 {
+			f.Add(i, x)
+			arr[i] += x
+		} else {
+			f.Set(i, x)
+			arr[i] = x
+		}
+		l := rand.Intn(n + 1)
+		r := l + rand.Intn(n+1-l)
+		want := 0
+		for _, v := range arr[l:r] {
+			want += v
+		}
+		if f.RangeSum(l, r) != want {
+			t.Fatalf("RangeSum(%d, %d)错误", l, r)
+		}
+	}
+	if !slices.Equal(f.ToSlice(), arr) {
+		t.Errorf("ToSlice错误")
+	}
+	clone := f.Clone()
+	f.Clear()
+	if f.Total() != 0 || !slices.Equal(clone.ToSlice(), arr) {
+		t.Errorf("Clear或Clone错误")
+	}
+
+	// 频率统计：找第k小的元素
+	cnt := NewFenwick[int](10)
+	for _, x := range []int{7, 2, 2, 9, 5} {
+		cnt.Add(x, 1)
+	}
+	if cnt.LowerBound(1) != 2 || cnt.LowerBound(3) != 5 || cnt.LowerBound(5) != 9 {
+		t.Errorf("第k小错误")
+	}
+}
+
+func Test区间修改树状数组(t *testing.T) {
+	f := NewRangeFenwickFromArray(NewArrayFromSlice([]float64{1, 2, 3, 4, 5}))
+	f.AddRange(1, 4, 0.5)
+	fmt.Println(f, "总和：", f.Total())
+	if !slices.Equal(f.ToSlice(), []float64{1, 2.5, 3.5, 4.5, 5}) || f.RangeSum(1, 3) != 6 || f.Total() != 16.5 {
+		t.Errorf("区间修改错误")
+	}
+
+	// 与暴力结果比较
+	n := 40
+	arr := make([]int64, n)
+	g := NewRangeFenwick[int64](n)
+	for range 2000 {
+		l := rand.Intn(n + 1)
+		r := l + rand.Intn(n+1-l)
+		x := int64(rand.Intn(21) - 10)
+		if rand.Intn(3) == 0 {
+			i := rand.Intn(n)
+			g.Add(i, x)
+			arr[i] += x
+		} else {
+			g.AddRange(l, r, x)
+			for i := l; i < r; i++ {
+				arr[i] += x
+			}
+		}
+		l = rand.Intn(n + 1)
+		r = l + rand.Intn(n+1-l)
+		var want int64
+		for _, v := range arr[l:r] {
+			want += v
+		}
+		if g.RangeSum(l, r) != want {
+			t.Fatalf("RangeSum(%d, %d)错误", l, r)
+		}
+	}
+	if !slices.Equal(g.ToSlice(), arr) || g.Get(n-1) != arr[n-1] {
+		t.Errorf("ToSlice错误")
+	}
+}
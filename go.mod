@@ -1,5 +1,5 @@
 module github.com/viocha/gods
 
-go 1.22
+go 1.24
 
 
@@ -0,0 +1,142 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// ===================================索引堆===================================
+
+// 按优先级排序的键集合，可以通过键修改优先级或删除元素，适用于Dijkstra等需要decrease-key的算法
+// 内部使用Heap，并记录每个键在堆中的位置
+type IndexedHeap[K comparable, V any] struct {
+	h    Heap[*heapItem[K, V]]
+	pos  map[K]*heapItem[K, V]
+	less func(V, V) bool
+}
+
+type heapItem[K comparable, V any] struct {
+	k K
+	v V
+	i int // 在堆中的位置
+}
+
+// 创建按优先级升序弹出的索引堆
+func NewIndexedHeap[K comparable, V cmp.Ordered]() IndexedHeap[K, V] {
+	return NewIndexedHeapFunc[K](func(a, b V) bool { return a <= b })
+}
+
+// 创建使用自定义less函数的索引堆，保证所有父元素j和子元素i满足less(j,i)
+func NewIndexedHeapFunc[K comparable, V any](less func(V, V) bool) IndexedHeap[K, V] {
+	o := IndexedHeap[K, V]{pos: map[K]*heapItem[K, V]{}, less: less}
+	o.h = NewHeap[*heapItem[K, V]]().WithLess(func(a, b *heapItem[K, V]) bool { return less(a.v, b.v) })
+	o.h.onMove = func(x *heapItem[K, V], i int) { x.i = i }
+	return o
+}
+
+// --------------------Container接口--------------------
+func (o IndexedHeap[K, V]) Len() int { return o.h.Len() }
+func (o IndexedHeap[K, V]) String() string { // 按堆的内部顺序输出
+	entries := make([]string, 0, o.Len())
+	for _, x := range *o.h.data {
+		entries = append(entries, fmt.Sprintf("%v:%v", x.k, x.v))
+	}
+	return "IndexedHeap[" + strings.Join(entries, " ") + "]"
+}
+func (o IndexedHeap[K, V]) Clear() IndexedHeap[K, V] {
+	*o.h.data = nil
+	clear(o.pos)
+	return o
+}
+func (o IndexedHeap[K, V]) Clone() IndexedHeap[K, V] {
+	res := NewIndexedHeapFunc[K](o.less)
+	for _, x := range *o.h.data {
+		y := *x
+		*res.h.data = append(*res.h.data, &y)
+		res.pos[y.k] = &y
+	}
+	return res
+}
+
+// 按弹出顺序遍历键和优先级，遍历过程中不能修改堆
+func (o IndexedHeap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for x := range o.h.All() {
+			if !yield(x.k, x.v) {
+				return
+			}
+		}
+	}
+}
+
+// --------------------基本操作--------------------
+
+// 插入键，若键已存在，则修改它的优先级
+func (o IndexedHeap[K, V]) Push(k K, v V) IndexedHeap[K, V] {
+	if o.Contains(k) {
+		return o.Update(k, v)
+	}
+	x := &heapItem[K, V]{k: k, v: v, i: o.Len()}
+	o.pos[k] = x
+	o.h.push(x)
+	return o
+}
+
+// 修改已存在的键的优先级，键不存在时会panic
+func (o IndexedHeap[K, V]) Update(k K, v V) IndexedHeap[K, V] {
+	x, ok := o.pos[k]
+	if !ok {
+		panic(fmt.Sprintf("键不存在：%v", k))
+	}
+	x.v = v
+	o.h.Set(x.i, x)
+	return o
+}
+
+// 删除键，键不存在时不做任何操作
+func (o IndexedHeap[K, V]) Remove(k K) IndexedHeap[K, V] {
+	if x, ok := o.pos[k]; ok {
+		delete(o.pos, k)
+		o.h.Del(x.i)
+	}
+	return o
+}
+
+func (o IndexedHeap[K, V]) Contains(k K) bool { _, ok := o.pos[k]; return ok }
+
+// 查找键的优先级，不存在时返回false
+func (o IndexedHeap[K, V]) Lookup(k K) (V, bool) {
+	if x, ok := o.pos[k]; ok {
+		return x.v, true
+	}
+	return *new(V), false
+}
+
+// 查看和弹出优先级最小的键，堆为空时会panic
+func (o IndexedHeap[K, V]) PeekMin() (K, V) {
+	x := o.h.Peek()
+	return x.k, x.v
+}
+func (o IndexedHeap[K, V]) PopMin() (K, V) {
+	x := o.h.Pop()
+	delete(o.pos, x.k)
+	return x.k, x.v
+}
+
+// 堆为空时返回false，不会panic
+func (o IndexedHeap[K, V]) TryPeekMin() (K, V, bool) {
+	if o.Len() == 0 {
+		return *new(K), *new(V), false
+	}
+	k, v := o.PeekMin()
+	return k, v, true
+}
+func (o IndexedHeap[K, V]) TryPopMin() (K, V, bool) {
+	if o.Len() == 0 {
+		return *new(K), *new(V), false
+	}
+	k, v := o.PopMin()
+	return k, v, true
+}
@@ -0,0 +1,93 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func Test索引堆(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	h.Push("a", 5).Push("b", 3).Push("c", 8).Push("d", 1)
+	fmt.Println("初始：", h)
+	h.Update("c", 0).Remove("d").Push("b", 9)
+	fmt.Println("修改c、删除d、修改b：", h)
+	if !h.Contains("a") || h.Contains("d") {
+		t.Errorf("Contains错误")
+	}
+	if v, ok := h.Lookup("b"); v != 9 || !ok {
+		t.Errorf("Lookup错误")
+	}
+	clone := h.Clone()
+	for _, want := range []string{"c", "a", "b"} {
+		k, v := h.PopMin()
+		fmt.Println("弹出：", k, v)
+		if k != want {
+			t.Errorf("弹出顺序错误，期望%v，得到%v", want, k)
+		}
+	}
+	if _, _, ok := h.TryPopMin(); ok {
+		t.Errorf("空堆TryPopMin应返回false")
+	}
+	fmt.Println("复制的堆按弹出顺序：")
+	for k, v := range clone.All() {
+		fmt.Print(k, ":", v, " ")
+	}
+	fmt.Println()
+	if clone.Len() != 3 {
+		t.Errorf("Clone后的堆被修改")
+	}
+
+	// Dijkstra最短路
+	graph := map[int][][2]int{0: {{1, 4}, {2, 1}}, 2: {{1, 2}, {3, 5}}, 1: {{3, 1}}}
+	dist := map[int]int{0: 0}
+	pq := NewIndexedHeap[int, int]().Push(0, 0)
+	for pq.Len() > 0 {
+		u, d := pq.PopMin()
+		for _, e := range graph[u] {
+			if old, ok := dist[e[0]]; !ok || d+e[1] < old {
+				dist[e[0]] = d + e[1]
+				pq.Push(e[0], d+e[1])
+			}
+		}
+	}
+	fmt.Println("最短路：", dist)
+	if dist[3] != 4 || dist[1] != 3 {
+		t.Errorf("Dijkstra结果错误")
+	}
+}
+
+func Test索引堆随机操作(t *testing.T) {
+	h := NewIndexedHeap[int, int]()
+	m := map[int]int{}
+	for range 5000 {
+		k := rand.Intn(100)
+		switch rand.Intn(3) {
+		case 0:
+			h.Push(k, rand.Intn(1000))
+			m[k], _ = h.Lookup(k)
+		case 1:
+			h.Remove(k)
+			delete(m, k)
+		case 2:
+			if h.Len() == 0 {
+				continue
+			}
+			k, v := h.PopMin()
+			for _, x := range m {
+				if x < v {
+					t.Fatalf("弹出的不是最小值")
+				}
+			}
+			delete(m, k)
+		}
+		for i, x := range *h.h.data {
+			if x.i != i || h.pos[x.k] != x {
+				t.Fatalf("位置记录错误")
+			}
+		}
+		if h.Len() != len(m) {
+			t.Fatalf("长度错误")
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// ===================================区间===================================
+
+// 闭区间[Lo, Hi]
+type Interval[K cmp.Ordered] struct {
+	Lo, Hi K
+}
+
+func (o Interval[K]) String() string { return fmt.Sprintf("[%v, %v]", o.Lo, o.Hi) }
+
+// 两个闭区间是否有公共点
+func (o Interval[K]) Overlaps(other Interval[K]) bool { return o.Lo <= other.Hi && other.Lo <= o.Hi }
+func (o Interval[K]) Contains(p K) bool               { return o.Lo <= p && p <= o.Hi }
+
+// 先按左端点，再按右端点比较
+func compareInterval[K cmp.Ordered](a, b Interval[K]) int {
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Hi, b.Hi)
+}
+
+// ===================================区间树===================================
+
+// 以闭区间为键的映射，基于增强有序映射，每个节点保存子树中区间右端点的最大值
+// 重叠查询的代价为O(min(n, k*logn))，k为结果个数，AnyOverlap为O(logn)
+// 允许插入相同的区间，相同的区间按插入顺序排列，各自保存一个值
+type IntervalTree[K cmp.Ordered, V any] struct {
+	m   AugmentedTreeMap[intervalKey[K], V, maxEnd[K]]
+	seq *uint64 // 下一个插入的序号，仅保存引用，以便值传递进行修改
+}
+
+// 区间树的键，使用插入序号区分相同的区间
+type intervalKey[K cmp.Ordered] struct {
+	Interval[K]
+	seq uint64
+}
+
+func compareIntervalKey[K cmp.Ordered](a, b intervalKey[K]) int {
+	return cmp.Or(compareInterval(a.Interval, b.Interval), cmp.Compare(a.seq, b.seq))
+}
+
+// 子树中区间右端点的最大值，空子树的ok为false
+type maxEnd[K cmp.Ordered] struct {
+	hi K
+	ok bool
+}
+
+func NewIntervalTree[K cmp.Ordered, V any]() IntervalTree[K, V] {
+	lift := func(k intervalKey[K], _ V) maxEnd[K] { return maxEnd[K]{k.Hi, true} }
+	combine := func(a, b maxEnd[K]) maxEnd[K] {
+		if !a.ok || b.ok && b.hi > a.hi {
+			return b
+		}
+		return a
+	}
+	return IntervalTree[K, V]{NewAugmentedTreeMapFunc(compareIntervalKey[K], maxEnd[K]{}, lift, combine), new(uint64)}
+}
+
+// 检查区间是否合法
+func newInterval[K cmp.Ordered](lo, hi K) Interval[K] {
+	if lo > hi {
+		panic(fmt.Sprintf("区间不合法：[%v, %v]", lo, hi))
+	}
+	return Interval[K]{lo, hi}
+}
+
+// --------------------Container接口--------------------
+func (o IntervalTree[K, V]) Len() int { return o.m.Len() }
+func (o IntervalTree[K, V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "IntervalTree[" + strings.Join(entries, " ") + "]"
+}
+func (o IntervalTree[K, V]) Clear() IntervalTree[K, V] { o.m.Clear(); return o }
+func (o IntervalTree[K, V]) Clone() IntervalTree[K, V] {
+	seq := *o.seq
+	return IntervalTree[K, V]{o.m.Clone(), &seq}
+}
+
+// 按左端点升序遍历，左端点相同时按右端点升序，区间相同时按插入顺序
+func (o IntervalTree[K, V]) All() iter.Seq2[Interval[K], V] {
+	return func(yield func(Interval[K], V) bool) {
+		for k, v := range o.m.All() {
+			if !yield(k.Interval, v) {
+				return
+			}
+		}
+	}
+}
+
+// --------------------基本操作--------------------
+
+// 插入闭区间[lo, hi]，区间已存在时也会插入新的一项，不会覆盖原来的值，lo大于hi时会panic
+func (o IntervalTree[K, V]) Insert(lo, hi K, v V) IntervalTree[K, V] {
+	o.m.Set(intervalKey[K]{newInterval(lo, hi), *o.seq}, v)
+	*o.seq++
+	return o
+}
+
+// 删除最早插入的闭区间[lo, hi]，区间不存在时不做任何操作
+func (o IntervalTree[K, V]) Delete(lo, hi K) IntervalTree[K, V] {
+	if e := o.first(lo, hi); e != nil {
+		o.m.Del(e.K)
+	}
+	return o
+}
+
+// 删除所有值满足f的闭区间[lo, hi]，用于删除相同区间中的指定项
+func (o IntervalTree[K, V]) DeleteFunc(lo, hi K, f func(V) bool) IntervalTree[K, V] {
+	var keys []intervalKey[K]
+	for k, v := range o.m.m.AscendFrom(intervalKey[K]{Interval[K]{lo, hi}, 0}) {
+		if k.Interval != (Interval[K]{lo, hi}) {
+			break
+		}
+		if f(v.v) {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		o.m.Del(k)
+	}
+	return o
+}
+func (o IntervalTree[K, V]) Has(lo, hi K) bool { return o.first(lo, hi) != nil }
+
+// 查找最早插入的闭区间[lo, hi]的值，不存在时返回false
+func (o IntervalTree[K, V]) Lookup(lo, hi K) (V, bool) {
+	if e := o.first(lo, hi); e != nil {
+		return e.V.v, true
+	}
+	return *new(V), false
+}
+
+// 最早插入的闭区间[lo, hi]，不存在时返回nil
+func (o IntervalTree[K, V]) first(lo, hi K) *MapEntry[intervalKey[K], augmentedValue[V, maxEnd[K]]] {
+	e := o.m.m.Ceiling(intervalKey[K]{Interval[K]{lo, hi}, 0})
+	if e == nil || e.K.Interval != (Interval[K]{lo, hi}) {
+		return nil
+	}
+	return e
+}
+
+// --------------------重叠查询--------------------
+
+// 按左端点升序遍历与[a, b]重叠的区间，跳过右端点最大值小于a的子树，O(min(n, k*logn))
+func (o IntervalTree[K, V]) Overlapping(a, b K) iter.Seq2[Interval[K], V] {
+	q := newInterval(a, b)
+	return func(yield func(Interval[K], V) bool) {
+		var visit func(node *avlNode[intervalKey[K], augmentedValue[V, maxEnd[K]]]) bool
+		visit = func(node *avlNode[intervalKey[K], augmentedValue[V, maxEnd[K]]]) bool {
+			if node == nil || node.v.agg.hi < q.Lo { // 子树中的区间都在q的左侧
+				return true
+			}
+			if !visit(node.left) {
+				return false
+			}
+			if node.k.Lo > q.Hi { // 该节点和右子树的区间都在q的右侧
+				return true
+			}
+			if node.k.Overlaps(q) && !yield(node.k.Interval, node.v.v) {
+				return false
+			}
+			return visit(node.right)
+		}
+		visit(o.m.m.root())
+	}
+}
+
+// 遍历包含点p的区间
+func (o IntervalTree[K, V]) Stabbing(p K) iter.Seq2[Interval[K], V] { return o.Overlapping(p, p) }
+
+// 任意一个与[a, b]重叠的区间，不存在时返回false，O(logn)
+func (o IntervalTree[K, V]) AnyOverlap(a, b K) (Interval[K], bool) {
+	q := newInterval(a, b)
+	node := o.m.m.root()
+	for node != nil && !node.k.Overlaps(q) {
+		// 左子树中存在右端点不小于a的区间时，若左子树中没有结果，右子树中也一定没有
+		if node.left != nil && node.left.v.agg.hi >= q.Lo {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	if node == nil {
+		return Interval[K]{}, false
+	}
+	return node.k.Interval, true
+}
+
+// ===================================区间集合===================================
+
+// 由互不相交的左闭右开区间组成的集合，插入时合并重叠和相邻的区间，删除时拆分区间
+type RangeSet[K cmp.Ordered] struct {
+	m TreeMap[K, K] // 左端点到右端点的映射，任意两个区间既不重叠也不相邻
+}
+
+func NewRangeSet[K cmp.Ordered]() RangeSet[K] { return RangeSet[K]{NewTreeMap[K, K]()} }
+
+// --------------------Container接口--------------------
+func (o RangeSet[K]) Len() int { return o.m.Len() } // 区间的个数
+func (o RangeSet[K]) String() string {
+	entries := make([]string, 0, o.Len())
+	for lo, hi := range o.All() {
+		entries = append(entries, fmt.Sprintf("[%v, %v)", lo, hi))
+	}
+	return "RangeSet[" + strings.Join(entries, " ") + "]"
+}
+func (o RangeSet[K]) Clear() RangeSet[K] { o.m.Clear(); return o }
+func (o RangeSet[K]) Clone() RangeSet[K] { return RangeSet[K]{o.m.Clone()} }
+
+// 按升序遍历每个区间的左右端点
+func (o RangeSet[K]) All() iter.Seq2[K, K] { return o.m.All() }
+
+// --------------------基本操作--------------------
+
+// 插入区间[lo, hi)，与已有的重叠或相邻的区间合并，lo大于等于hi时不做任何操作，O(logn)
+func (o RangeSet[K]) Add(lo, hi K) RangeSet[K] {
+	if lo >= hi {
+		return o
+	}
+	if e := o.m.Floor(lo); e != nil && e.V >= lo { // 左侧与lo重叠或相邻的区间
+		lo, hi = e.K, max(hi, e.V)
+	}
+	if e := o.m.Floor(hi); e != nil && e.V > hi { // 右侧与hi重叠或相邻的区间
+		hi = e.V
+	}
+	o.m.DelRange(lo, hi, true, true)
+	o.m.Set(lo, hi)
+	return o
+}
+
+// 删除区间[lo, hi)，部分重叠的区间会被截断或拆分为两个区间，O(logn)
+func (o RangeSet[K]) Remove(lo, hi K) RangeSet[K] {
+	if lo >= hi {
+		return o
+	}
+	if e := o.m.Lower(hi); e != nil && e.V > hi { // 保留超出hi的部分
+		o.m.Set(hi, e.V)
+	}
+	if e := o.m.Lower(lo); e != nil && e.V > lo { // 保留lo之前的部分
+		o.m.Set(e.K, lo)
+	}
+	o.m.DelRange(lo, hi, true, false)
+	return o
+}
+
+// 是否包含点p
+func (o RangeSet[K]) Has(p K) bool {
+	e := o.m.Floor(p)
+	return e != nil && p < e.V
+}
+
+// 是否完整包含区间[lo, hi)
+func (o RangeSet[K]) Covers(lo, hi K) bool {
+	if lo >= hi {
+		return true
+	}
+	e := o.m.Floor(lo)
+	return e != nil && hi <= e.V
+}
+
+// 是否与区间[lo, hi)有公共部分
+func (o RangeSet[K]) Overlaps(lo, hi K) bool {
+	if lo >= hi {
+		return false
+	}
+	e := o.m.Lower(hi)
+	return e != nil && e.V > lo
+}
+
+// 包含点p的区间，不存在时返回false
+func (o RangeSet[K]) RangeOf(p K) (lo, hi K, ok bool) {
+	if e := o.m.Floor(p); e != nil && p < e.V {
+		return e.K, e.V, true
+	}
+	return lo, hi, false
+}
@@ -0,0 +1,187 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test区间树(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(1, 5, "a").Insert(3, 8, "b").Insert(10, 12, "c").Insert(6, 6, "d").Insert(1, 5, "A")
+	fmt.Println(tree)
+	if tree.Len() != 5 || func() bool { v, _ := tree.Lookup(1, 5); return v != "a" }() {
+		t.Errorf("插入错误：%v", tree)
+	}
+	var got []string
+	for _, v := range tree.Overlapping(5, 9) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"a", "A", "b", "d"}) {
+		t.Errorf("Overlapping错误：%v", got)
+	}
+	got = nil
+	for _, v := range tree.Stabbing(12) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"c"}) {
+		t.Errorf("Stabbing错误：%v", got)
+	}
+	if _, ok := tree.AnyOverlap(13, 20); ok {
+		t.Errorf("AnyOverlap应返回false")
+	}
+	tree.Delete(10, 12).Delete(0, 100)
+	if tree.Len() != 4 || tree.Has(10, 12) {
+		t.Errorf("删除错误：%v", tree)
+	}
+
+	// 与暴力结果比较，entries按插入顺序保存所有区间
+	type entry struct {
+		iv Interval[int]
+		v  int
+	}
+	tree2 := NewIntervalTree[int, int]()
+	var entries []entry
+	for i := range 3000 {
+		lo := rand.Intn(100)
+		iv := Interval[int]{lo, lo + rand.Intn(10)}
+		if rand.Intn(3) == 0 {
+			tree2.Delete(iv.Lo, iv.Hi) // 删除最早插入的一项
+			if j := slices.IndexFunc(entries, func(e entry) bool { return e.iv == iv }); j >= 0 {
+				entries = slices.Delete(entries, j, j+1)
+			}
+		} else {
+			tree2.Insert(iv.Lo, iv.Hi, i)
+			entries = append(entries, entry{iv, i})
+		}
+		a := rand.Intn(120)
+		q := Interval[int]{a, a + rand.Intn(10)}
+		var want []entry
+		for _, e := range entries {
+			if e.iv.Overlaps(q) {
+				want = append(want, e)
+			}
+		}
+		slices.SortStableFunc(want, func(a, b entry) int { return compareInterval(a.iv, b.iv) })
+		var res []entry
+		for iv, v := range tree2.Overlapping(q.Lo, q.Hi) {
+			res = append(res, entry{iv, v})
+		}
+		if !slices.Equal(res, want) {
+			t.Fatalf("Overlapping(%v)错误：%v，应为%v", q, res, want)
+		}
+		if iv, ok := tree2.AnyOverlap(q.Lo, q.Hi); ok != (len(want) > 0) || ok && !iv.Overlaps(q) {
+			t.Fatalf("AnyOverlap(%v)错误：%v", q, iv)
+		}
+	}
+	if tree2.Len() != len(entries) {
+		t.Errorf("长度错误")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("非法区间应panic")
+		}
+	}()
+	tree2.Insert(2, 1, 0)
+}
+
+func Test区间树相同区间(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(9, 11, "alice").Insert(9, 11, "bob").Insert(10, 12, "carol")
+	if tree.Len() != 3 {
+		t.Fatalf("相同的区间不应覆盖：%v", tree)
+	}
+	var got []string
+	for _, v := range tree.Stabbing(10) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"alice", "bob", "carol"}) {
+		t.Errorf("Stabbing错误：%v", got)
+	}
+
+	clone := tree.Clone().Insert(9, 11, "dave")
+	tree.DeleteFunc(9, 11, func(v string) bool { return v == "bob" })
+	if v, ok := tree.Lookup(9, 11); !ok || v != "alice" || tree.Len() != 2 || clone.Len() != 4 {
+		t.Errorf("DeleteFunc错误：%v %v", tree, clone)
+	}
+	tree.Delete(9, 11)
+	if tree.Has(9, 11) || tree.Len() != 1 {
+		t.Errorf("删除后仍存在：%v", tree)
+	}
+	clone.Delete(9, 11) // 删除最早插入的alice
+	if v, _ := clone.Lookup(9, 11); v != "bob" || clone.Len() != 3 {
+		t.Errorf("Delete应删除最早插入的一项：%v", clone)
+	}
+}
+
+func Test区间集合(t *testing.T) {
+	s := NewRangeSet[int]()
+	s.Add(1, 3).Add(5, 7).Add(3, 4).Add(10, 10)
+	fmt.Println(s)
+	if s.String() != "RangeSet[[1, 4) [5, 7)]" {
+		t.Errorf("合并错误：%v", s)
+	}
+	s.Add(4, 5)
+	if s.Len() != 1 || !s.Covers(1, 7) {
+		t.Errorf("合并相邻区间错误：%v", s)
+	}
+	s.Remove(2, 3)
+	fmt.Println(s)
+	if s.String() != "RangeSet[[1, 2) [3, 7)]" || s.Has(2) || !s.Has(3) || s.Has(7) {
+		t.Errorf("拆分错误：%v", s)
+	}
+	if lo, hi, ok := s.RangeOf(5); lo != 3 || hi != 7 || !ok {
+		t.Errorf("RangeOf错误")
+	}
+	if s.Overlaps(2, 3) || !s.Overlaps(0, 2) {
+		t.Errorf("Overlaps错误")
+	}
+
+	// 与暴力结果比较
+	s.Clear()
+	const n = 100
+	var covered [n]bool
+	for range 3000 {
+		lo, hi := rand.Intn(n), rand.Intn(n)
+		add := rand.Intn(2) == 0
+		if add {
+			s.Add(lo, hi)
+		} else {
+			s.Remove(lo, hi)
+		}
+		for i := lo; i < hi; i++ {
+			covered[i] = add
+		}
+		// 按覆盖情况计算应有的区间
+		var want [][2]int
+		for i := 0; i < n; i++ {
+			if covered[i] && (i == 0 || !covered[i-1]) {
+				want = append(want, [2]int{i, i})
+			}
+			if covered[i] {
+				want[len(want)-1][1] = i + 1
+			}
+		}
+		var res [][2]int
+		for lo, hi := range s.All() {
+			res = append(res, [2]int{lo, hi})
+		}
+		if !slices.Equal(res, want) {
+			t.Fatalf("区间错误：%v，应为%v", res, want)
+		}
+		p := rand.Intn(n)
+		if s.Has(p) != covered[p] {
+			t.Fatalf("Has(%d)错误", p)
+		}
+		a, b := rand.Intn(n), rand.Intn(n)
+		all, some := true, false
+		for i := a; i < b; i++ {
+			all, some = all && covered[i], some || covered[i]
+		}
+		if s.Covers(a, b) != all || s.Overlaps(a, b) != some {
+			t.Fatalf("Covers/Overlaps(%d, %d)错误", a, b)
+		}
+	}
+}
@@ -0,0 +1,475 @@
+package gods
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// ===================================JSON编解码===================================
+
+// 单值容器编码成JSON数组，映射编码成JSON对象，多重集编码成{元素:重数}的JSON对象
+// 自定义哈希表的键不一定能作为JSON对象的键，编码成[{"K":键,"V":值}]的JSON数组
+// 解码时支持零值容器，非零值容器会先清空，并保留比较函数、工厂函数、容量等设置
+
+// --------------------辅助函数--------------------
+
+func isJSONNull(data []byte) bool { return string(bytes.TrimSpace(data)) == "null" }
+
+// 将键值对按顺序编码成JSON对象，键的编码规则和encoding/json中的map相同
+func marshalEntries[K comparable, V any](entries iter.Seq2[K, V]) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	first := true
+	for k, v := range entries {
+		b, err := json.Marshal(map[K]V{k: v})
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		buf.Write(b[1 : len(b)-1]) // 去掉花括号，只保留键值对
+		first = false
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// 按顺序解码JSON对象中的键值对
+func unmarshalEntries[K comparable, V any](data []byte, set func(K, V)) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("gods: 需要JSON对象，得到%v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k, err := parseJSONKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		set(k, v)
+	}
+	_, err := dec.Token() // 结尾的'}'
+	return err
+}
+
+// 按encoding/json中map的规则，将JSON对象的键转换成K类型
+func parseJSONKey[K comparable](s string) (K, error) {
+	quoted, _ := json.Marshal(s)
+	m := map[K]struct{}{}
+	if err := json.Unmarshal(append(append([]byte{'{'}, quoted...), ":null}"...), &m); err != nil {
+		return *new(K), err
+	}
+	for k := range m {
+		return k, nil
+	}
+	return *new(K), nil
+}
+
+// --------------------Optional--------------------
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Exists() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.v)
+}
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		o.v = nil
+		return nil
+	}
+	v := new(T)
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	o.v = v
+	return nil
+}
+
+// --------------------Array和List--------------------
+
+func (o Array[T]) MarshalJSON() ([]byte, error) {
+	if o.data == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.data)
+}
+func (o *Array[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewArray[T]()
+	}
+	*o.data = s
+	return nil
+}
+
+func (o List[T]) MarshalJSON() ([]byte, error) {
+	if o.root == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *List[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.root == nil {
+		*o = NewList[T]()
+	}
+	o.Clear()
+	for _, v := range s {
+		o.PushBack(v)
+	}
+	return nil
+}
+
+// --------------------栈、队列和堆--------------------
+
+// 栈从栈底到栈顶编码
+func (o Stack[T]) MarshalJSON() ([]byte, error) {
+	if o.data == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.data)
+}
+func (o *Stack[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewStack[T]()
+	}
+	*o.data = s
+	return nil
+}
+
+// 队列从队首到队尾编码
+func (o Queue[T]) MarshalJSON() ([]byte, error) {
+	if o.d == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *Queue[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.d == nil {
+		*o = NewQueue[T]()
+	}
+	o.Clear()
+	for _, v := range s {
+		o.PushBack(v)
+	}
+	return nil
+}
+
+// 堆按弹出顺序编码
+func (o Heap[T]) MarshalJSON() ([]byte, error) {
+	if o.data == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(slices.Collect(o.All()))
+}
+func (o *Heap[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.data == nil {
+		*o = NewHeap[T]()
+	}
+	if o.less == nil {
+		return errNoNaturalOrder[T]()
+	}
+	*o = o.WithSlice(s)
+	return nil
+}
+
+// --------------------集合--------------------
+
+func (o HashSet[T]) MarshalJSON() ([]byte, error) {
+	if o.m.m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *HashSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.m.m == nil {
+		*o = NewHashSet[T]()
+	}
+	o.Clear()
+	for _, v := range s {
+		o.Add(v)
+	}
+	return nil
+}
+
+// 按链表顺序编码
+func (o LinkedHashSet[T]) MarshalJSON() ([]byte, error) {
+	if o.m.m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *LinkedHashSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.m.m == nil {
+		*o = NewLinkedHashSet[T]()
+	}
+	o.Clear()
+	for _, v := range s {
+		o.Add(v)
+	}
+	return nil
+}
+
+// 按升序编码
+func (o TreeSet[T]) MarshalJSON() ([]byte, error) {
+	if o.m.dummyRoot == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *TreeSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if o.m.dummyRoot == nil {
+		m, err := newNaturalTreeMap[T, struct{}]()
+		if err != nil {
+			return err
+		}
+		*o = TreeSet[T]{m: m}
+	}
+	o.Clear()
+	for _, v := range s {
+		o.Add(v)
+	}
+	return nil
+}
+
+// 顺序不确定，解码时需要先使用NewCustomHashSet创建集合，以提供Hasher
+func (o CustomHashSet[T]) MarshalJSON() ([]byte, error) {
+	if o.m.m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.ToSlice())
+}
+func (o *CustomHashSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	if o.m.m == nil {
+		return errNoHasher[T]()
+	}
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	o.Clear()
+	for _, v := range s {
+		o.Add(v)
+	}
+	return nil
+}
+
+// --------------------多重集--------------------
+
+func (o MultiHashSet[T]) MarshalJSON() ([]byte, error) {
+	if o.total == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.m.m)
+}
+func (o *MultiHashSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var m map[T]int
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if o.total == nil {
+		*o = NewMultiHashSet[T]()
+	}
+	o.Clear()
+	for x, cnt := range m {
+		o.AddN(x, cnt)
+	}
+	return nil
+}
+
+// 按升序编码
+func (o MultiTreeSet[T]) MarshalJSON() ([]byte, error) {
+	if o.sumMap == nil {
+		return []byte("null"), nil
+	}
+	return marshalEntries(o.All())
+}
+func (o *MultiTreeSet[T]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	if o.sumMap == nil {
+		c, err := naturalCmp[T]()
+		if err != nil {
+			return err
+		}
+		*o = NewMultiTreeSetFunc(c)
+	}
+	o.Clear()
+	return unmarshalEntries(data, func(x T, cnt int) { o.AddN(x, cnt) })
+}
+
+// --------------------映射--------------------
+
+func (o HashMap[K, V]) MarshalJSON() ([]byte, error) {
+	if o.m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.m)
+}
+func (o *HashMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if o.m == nil {
+		*o = NewHashMap[K, V]()
+	}
+	o.Clear()
+	for k, v := range m {
+		o.Set(k, v)
+	}
+	return nil
+}
+
+// 按链表顺序编码，解码时保持JSON对象中键的顺序
+func (o LinkedHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	if o.m == nil {
+		return []byte("null"), nil
+	}
+	return marshalEntries(o.All())
+}
+func (o *LinkedHashMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	if o.m == nil {
+		*o = NewLinkedHashMap[K, V]()
+	}
+	o.Clear()
+	return unmarshalEntries(data, func(k K, v V) { o.Set(k, v) })
+}
+
+// 按键的升序编码
+func (o TreeMap[K, V]) MarshalJSON() ([]byte, error) {
+	if o.dummyRoot == nil {
+		return []byte("null"), nil
+	}
+	return marshalEntries(o.All())
+}
+func (o *TreeMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	if o.dummyRoot == nil {
+		m, err := newNaturalTreeMap[K, V]()
+		if err != nil {
+			return err
+		}
+		*o = m
+	}
+	o.Clear()
+	return unmarshalEntries(data, func(k K, v V) { o.Set(k, v) })
+}
+
+// 自定义哈希表中的一个键值对
+type jsonEntry[K, V any] struct {
+	K K
+	V V
+}
+
+// 顺序不确定，解码时需要先使用NewCustomHashMap创建映射，以提供Hasher
+func (o CustomHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	if o.m == nil {
+		return []byte("null"), nil
+	}
+	entries := make([]jsonEntry[K, V], 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, jsonEntry[K, V]{k, v})
+	}
+	return json.Marshal(entries)
+}
+func (o *CustomHashMap[K, V]) UnmarshalJSON(data []byte) error {
+	if isJSONNull(data) {
+		return nil
+	}
+	if o.m == nil {
+		return errNoHasher[K]()
+	}
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	o.Clear()
+	for _, e := range entries {
+		o.Set(e.K, e.V)
+	}
+	return nil
+}
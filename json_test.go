@@ -0,0 +1,147 @@
+package gods
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestJSON编码(t *testing.T) {
+	type resp struct {
+		Arr   Array[int]
+		Queue Queue[string]
+		Set   TreeSet[int]
+		Lhm   LinkedHashMap[string, int]
+		Tm    TreeMap[int, string]
+		Ms    MultiTreeSet[string]
+		Opt   Optional[int]
+		None  Optional[int]
+	}
+	r := resp{
+		Arr:   ValArr(1, 2, 3),
+		Queue: NewQueue[string]().Push("a").Push("b"),
+		Set:   NewTreeSetFromSlice([]int{3, 1, 2}),
+		Lhm:   NewLinkedHashMap[string, int]().Set("z", 1).Set("a", 2),
+		Tm:    NewTreeMap[int, string]().Set(2, "b").Set(1, "a"),
+		Ms:    NewMultiTreeSetFromSlice([]string{"x", "y", "x"}),
+		Opt:   NewOptional[int]().WithValue(5),
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Arr":[1,2,3],"Queue":["a","b"],"Set":[1,2,3],"Lhm":{"z":1,"a":2},"Tm":{"1":"a","2":"b"},"Ms":{"x":2,"y":1},"Opt":5,"None":null}`
+	if string(b) != want {
+		t.Errorf("编码结果：%s", b)
+	}
+
+	var got resp // 解码到零值
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println(got.Arr, got.Queue, got.Set, got.Lhm, got.Tm, got.Ms, got.Opt.GetOr(-1), got.None.Exists())
+	if !slices.Equal(got.Lhm.KeySlice(), []string{"z", "a"}) || got.Tm.Get(2) != "b" || got.Ms.Count("x") != 2 {
+		t.Errorf("解码结果不一致")
+	}
+}
+
+func TestJSON解码保留设置(t *testing.T) {
+	m := NewLinkedHashMap[int, int]().WithMaxCap(2)
+	if err := json.Unmarshal([]byte(`{"1":1,"2":2,"3":3}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(m.KeySlice(), []int{2, 3}) {
+		t.Errorf("maxCap没有保留：%v", m)
+	}
+
+	s := NewTreeSetFunc(func(a, b int) int { return b - a })
+	if err := json.Unmarshal([]byte(`[1,3,2]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	fmt.Println("降序集合：", s)
+
+	var h Heap[int]
+	if err := json.Unmarshal([]byte(`[5,1,3]`), &h); err != nil || h.Peek() != 1 {
+		t.Errorf("堆解码：%v %v", h, err)
+	}
+	var hs HashSet[string]
+	var mh MultiHashSet[int]
+	var st Stack[int]
+	var l List[int]
+	for _, c := range []struct {
+		data string
+		v    any
+	}{{`["a","b"]`, &hs}, {`{"1":2}`, &mh}, {`[1,2]`, &st}, {`[1,2]`, &l}} {
+		if err := json.Unmarshal([]byte(c.data), c.v); err != nil {
+			t.Error(err)
+		}
+	}
+	fmt.Println(hs, mh, mh.Total(), st, l)
+
+	type key struct{ a, b int }
+	var bad TreeMap[key, int]
+	if err := json.Unmarshal([]byte(`{}`), &bad); err == nil {
+		t.Errorf("没有自然排序的键应该返回错误")
+	}
+}
+
+func TestJSON自定义哈希容器(t *testing.T) {
+	s := NewCustomHashSet(SliceHasher[int]()).Add([]int{1, 2}).Add([]int{3})
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewCustomHashSet(SliceHasher[int]())
+	if err := json.Unmarshal(b, &got); err != nil || got.Len() != 2 || !got.Has([]int{1, 2}) || !got.Has([]int{3}) {
+		t.Errorf("CustomHashSet编解码错误：%s %v %v", b, got, err)
+	}
+
+	m := NewCustomHashMap[[]int, string](SliceHasher[int]()).Set([]int{1}, "a")
+	if b, err = json.Marshal(m); err != nil || string(b) != `[{"K":[1],"V":"a"}]` {
+		t.Errorf("CustomHashMap编码错误：%s %v", b, err)
+	}
+	gotMap := NewCustomHashMap[[]int, string](SliceHasher[int]()).Set([]int{2}, "b")
+	if err := json.Unmarshal(b, &gotMap); err != nil || gotMap.Len() != 1 || gotMap.Get([]int{1}) != "a" {
+		t.Errorf("CustomHashMap解码错误：%v %v", gotMap, err)
+	}
+
+	// 零值没有Hasher，无法解码
+	var zeroSet CustomHashSet[[]int]
+	var zeroMap CustomHashMap[[]int, string]
+	if json.Unmarshal([]byte(`[[1]]`), &zeroSet) == nil || json.Unmarshal(b, &zeroMap) == nil {
+		t.Errorf("解码到零值应该返回错误")
+	}
+}
+
+func TestJSON解码使用自然排序(t *testing.T) {
+	var m TreeMap[int, string]
+	var s TreeSet[string]
+	var ms MultiTreeSet[float64]
+	for _, c := range []struct {
+		data string
+		v    any
+	}{{`{"2":"b","1":"a"}`, &m}, {`["b","a"]`, &s}, {`{"1.5":2}`, &ms}} {
+		if err := json.Unmarshal([]byte(c.data), c.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 常见类型不使用反射，解码得到的集合可以和NewTreeSet创建的集合直接拆分合并
+	if !sameFunc(m.cmp, cmp.Compare[int]) || !sameFunc(s.m.cmp, cmp.Compare[string]) || !sameFunc(ms.m.cmp, cmp.Compare[float64]) {
+		t.Errorf("解码零值有序容器时没有使用cmp.Compare")
+	}
+	if !s.m.sameOrder(NewTreeSet[string]().m) || !slices.Equal(s.Union(NewTreeSetFromSlice([]string{"c"})).ToSlice(), []string{"a", "b", "c"}) {
+		t.Errorf("解码得到的集合应使用自然顺序的标识：%v", s)
+	}
+
+	type celsius float64 // 自定义类型使用反射
+	var named TreeSet[celsius]
+	if err := json.Unmarshal([]byte(`[3.5,-1]`), &named); err != nil || named.First() != -1 {
+		t.Errorf("自定义类型解码错误：%v %v", named, err)
+	}
+}
+
+// 两个函数是否为同一个函数
+func sameFunc(a, b any) bool { return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer() }
@@ -3,6 +3,7 @@ package gods
 import (
 	"cmp"
 	"fmt"
+	"iter"
 	"math/rand"
 	"reflect"
 	"slices"
@@ -103,6 +104,37 @@ func (o List[T]) ToSlice() []T {
 }
 func (o List[T]) String() string { return "List" + fmt.Sprint(o.ToSlice()) }
 
+// --------------------迭代器--------------------
+
+// 从前往后遍历索引和值，遍历时可以删除当前节点
+func (o List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for p := o.Front(); p != nil; i++ {
+			nex := p.Next()
+			if !yield(i, p.Val) {
+				return
+			}
+			p = nex
+		}
+	}
+}
+func (o List[T]) Values() iter.Seq[T] { return seqValues(o.All()) }
+
+// 从后往前遍历索引和值
+func (o List[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := o.Len() - 1
+		for p := o.Back(); p != nil; i-- {
+			pre := p.Prev()
+			if !yield(i, p.Val) {
+				return
+			}
+			p = pre
+		}
+	}
+}
+
 // --------------------List接口--------------------
 
 // 基本操作
@@ -147,34 +179,18 @@ type Array[T any] struct {
 
 // 创建空数组
 func NewArray[T any]() Array[T] {
-	var compare func(T, T) int
 	Val := reflect.ValueOf
-	v := Val(*new(T))
-
-	if v.CanInt() {
-		compare = func(a, b T) int {
-			return cmp.Compare[int64](Val(a).Int(), Val(b).Int())
-		}
-	} else if v.CanUint() {
-		compare = func(a, b T) int {
-			return cmp.Compare[uint64](Val(a).Uint(), Val(b).Uint())
-		}
-	} else if v.CanFloat() {
-		compare = func(a, b T) int {
-			return cmp.Compare[float64](Val(a).Float(), Val(b).Float())
-		}
-	} else if v.Kind() == reflect.String {
-		compare = func(a, b T) int {
-			return cmp.Compare[string](Val(a).String(), Val(b).String())
-		}
-	}
-
 	eq := func(a, b T) bool { return Val(a).Equal(Val(b)) }
 
-	o := Array[T]{data: new([]T), cmp: compare, eq: eq}
+	o := Array[T]{data: new([]T), cmp: reflectCmp[T](), eq: eq}
 	return o
 }
 
+// 创建按自然排序比较的空数组，不使用反射
+func NewOrderedArray[T cmp.Ordered]() Array[T] {
+	return Array[T]{data: new([]T), cmp: cmp.Compare[T], eq: func(a, b T) bool { return a == b }}
+}
+
 // 创建一个具有大小n的数组
 func MakeArray[T any](n int) Array[T] {
 	arr := NewArray[T]()
@@ -216,7 +232,6 @@ func (o Array[T]) String() string             { return "Array" + fmt.Sprint(*o.d
 
 // ---------------遍历和转换---------------
 
-func (o Array[T]) Keys() Array[int]                { return RangeN(o.Len()) }
 func (o Array[T]) ForEachIdx(f func(int)) Array[T] { return o.ForEachIdxVal(func(i int, v T) { f(i) }) }
 func (o Array[T]) ForEachIdxVal(f func(int, T)) Array[T] {
 	for i, v := range o.ToSlice() {
@@ -225,6 +240,12 @@ func (o Array[T]) ForEachIdxVal(f func(int, T)) Array[T] {
 	return o
 }
 
+// ---------------迭代器---------------
+func (o Array[T]) All() iter.Seq2[int, T]      { return slices.All(*o.data) }
+func (o Array[T]) Keys() iter.Seq[int]         { return seqKeys(o.All()) }
+func (o Array[T]) Values() iter.Seq[T]         { return slices.Values(*o.data) }
+func (o Array[T]) Backward() iter.Seq2[int, T] { return slices.Backward(*o.data) }
+
 // ---------------基本操作---------------
 // 查询值，支持负值索引
 func (o Array[T]) Get(i int) T             { return (*o.data)[o.idx(i)] }
@@ -256,6 +277,23 @@ func (o Array[T]) Pop() T {
 	return x
 }
 
+// 索引超出范围或数组为空时返回false，不会panic
+func (o Array[T]) TryGet(i int) (T, bool) {
+	if i < 0 {
+		i += o.Len()
+	}
+	if i < 0 || i >= o.Len() {
+		return *new(T), false
+	}
+	return (*o.data)[i], true
+}
+func (o Array[T]) TryPop() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Pop(), true
+}
+
 // 支持负值索引
 func (o Array[T]) idx(i int) int {
 	preIdx := i
@@ -466,6 +504,16 @@ func (o Array[T]) Sum() T {
 	return *res
 }
 
+// 不使用反射的求和与最值，支持自定义的数值类型，数组为空时MinOf和MaxOf会panic
+func SumOf[T Number](arr Array[T]) (sum T) {
+	for _, x := range *arr.data {
+		sum += x
+	}
+	return sum
+}
+func MinOf[T cmp.Ordered](arr Array[T]) T { return slices.Min(*arr.data) }
+func MaxOf[T cmp.Ordered](arr Array[T]) T { return slices.Max(*arr.data) }
+
 // 如果通过判断是否和零值相等，转换成true或者false
 func (o Array[T]) Any() bool {
 	zero := *new(T)
@@ -476,7 +524,7 @@ func (o Array[T]) Any() bool {
 	}
 	return false
 }
-func (o Array[T]) All() bool {
+func (o Array[T]) Every() bool {
 	zero := *new(T)
 	for _, x := range *o.data {
 		if o.cmp(x, zero) == 0 {
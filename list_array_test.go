@@ -2,6 +2,7 @@ package gods
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"testing"
 )
@@ -60,3 +61,82 @@ func TestRange(t *testing.T) {
 	fmt.Println("flatMap操作：", arr.FlatMapToInt(func(v int) Array[int] { return RangeEq(1, v) }))
 	fmt.Println("flatMap操作：", arr.FlatMap(func(v int) Array[int] { return RangeEq(1, v) }))
 }
+
+func Test迭代器(t *testing.T) {
+	a := Range(0, 5)
+	for i, v := range a.All() {
+		if i != v {
+			t.Errorf("All索引和值不一致：%v %v", i, v)
+		}
+	}
+	fmt.Println("Backward遍历：", slices.Collect(seqValues(a.Backward())))
+	fmt.Println("Keys：", slices.Collect(a.Keys()))
+
+	l := NewList[int]()
+	for i := range 5 {
+		l.PushBack(i)
+	}
+	for i, v := range l.All() {
+		if v == 3 {
+			break
+		}
+		fmt.Println(i, v)
+	}
+	if got := slices.Collect(l.Values()); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("List.Values = %v", got)
+	}
+}
+
+func Test数组Try操作(t *testing.T) {
+	a := ValArr(1, 2, 3)
+	fmt.Println(a.TryGet(-1))
+	fmt.Println(a.TryGet(3))
+	if v, ok := a.TryGet(-3); v != 1 || !ok {
+		t.Errorf("TryGet(-3)错误")
+	}
+	if _, ok := a.TryGet(-4); ok {
+		t.Errorf("TryGet(-4)应返回false")
+	}
+	a.Clear()
+	if _, ok := a.TryPop(); ok {
+		t.Errorf("空数组TryPop应返回false")
+	}
+}
+
+func Test有序数组聚合(t *testing.T) {
+	a := Arr([]score{3, 1, 2})
+	fmt.Println(SumOf(a), MinOf(a), MaxOf(a), a.Sum())
+	if SumOf(a) != 6 || MinOf(a) != 1 || MaxOf(a) != 3 || a.Sum() != 6 {
+		t.Errorf("聚合结果错误")
+	}
+	b := NewOrderedArray[score]().Push(3, 1, 2).Sort()
+	fmt.Println(b, b.IndexBi(2), b.Has(3))
+	if b.IndexBi(2) != 1 || !b.Has(3) {
+		t.Errorf("有序数组查找错误")
+	}
+}
+
+func BenchmarkSum反射(b *testing.B) {
+	a := RangeN(10000)
+	for b.Loop() {
+		a.Sum()
+	}
+}
+func BenchmarkSumOf(b *testing.B) {
+	a := RangeN(10000)
+	for b.Loop() {
+		SumOf(a)
+	}
+}
+func BenchmarkMax反射(b *testing.B) {
+	a := RangeN(10000)
+	for b.Loop() {
+		a.Max()
+	}
+}
+func BenchmarkMaxOf(b *testing.B) {
+	a := RangeN(10000)
+	for b.Loop() {
+		MaxOf(a)
+	}
+}
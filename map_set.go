@@ -2,7 +2,9 @@ package gods
 
 import (
 	"fmt"
+	"iter"
 	"maps"
+	"slices"
 	"strings"
 )
 
@@ -41,6 +43,11 @@ func (o HashMap[K, V]) ForEach(f func(K, V)) HashMap[K, V] {
 }
 func (o HashMap[K, V]) ToMap() map[K]V { return maps.Clone(o.m) }
 
+// 迭代器，顺序不确定
+func (o HashMap[K, V]) All() iter.Seq2[K, V] { return maps.All(o.m) }
+func (o HashMap[K, V]) Keys() iter.Seq[K]    { return maps.Keys(o.m) }
+func (o HashMap[K, V]) Values() iter.Seq[V]  { return maps.Values(o.m) }
+
 // 基本操作
 func (o HashMap[K, V]) Has(k K) bool               { _, ok := o.m[k]; return ok }
 func (o HashMap[K, V]) Set(k K, v V) HashMap[K, V] { o.m[k] = v; return o }
@@ -52,6 +59,12 @@ func (o HashMap[K, V]) Get(k K) V {
 	return o.m[k]
 }
 
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o HashMap[K, V]) Lookup(k K) (V, bool) {
+	v, ok := o.m[k]
+	return v, ok
+}
+
 // 组合操作
 func (o HashMap[K, V]) GetOr(k K, v V) V {
 	if !o.Has(k) {
@@ -80,17 +93,9 @@ func (o HashMap[K, V]) ReplaceFunc(f func(K, V) V) HashMap[K, V] {
 	return o.ForEach(func(k K, v V) { o.Set(k, f(k, v)) })
 }
 
-// 键值查询
-func (o HashMap[K, V]) Keys() []K {
-	var keys []K
-	o.ForEach(func(k K, v V) { keys = append(keys, k) })
-	return keys
-}
-func (o HashMap[K, V]) Values() []V {
-	var values []V
-	o.ForEach(func(k K, v V) { values = append(values, v) })
-	return values
-}
+// 键值列表查询
+func (o HashMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o HashMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
 
 // ===================================哈希集===================================
 type HashSet[T comparable] struct {
@@ -110,13 +115,16 @@ func NewHashSetFromSlice[T comparable](s []T) HashSet[T] {
 func (o HashSet[T]) Len() int          { return o.m.Len() }
 func (o HashSet[T]) Clear() HashSet[T] { o.m.Clear(); return o }
 func (o HashSet[T]) Clone() HashSet[T] { o.m = o.m.Clone(); return o }
-func (o HashSet[T]) String() string    { return "HashSet" + fmt.Sprint(o.m.Keys()) }
-func (o HashSet[T]) ToSlice() []T      { return o.m.Keys() }
+func (o HashSet[T]) String() string    { return "HashSet" + fmt.Sprint(o.ToSlice()) }
+func (o HashSet[T]) ToSlice() []T      { return o.m.KeySlice() }
 func (o HashSet[T]) ForEach(f func(T)) HashSet[T] {
 	o.m.ForEach(func(k T, v struct{}) { f(k) })
 	return o
 }
 
+// 迭代器，顺序不确定
+func (o HashSet[T]) All() iter.Seq[T] { return o.m.Keys() }
+
 // --------------------Set接口--------------------
 
 // 基本操作
@@ -285,6 +293,32 @@ func (o LinkedHashMap[K, V]) ToMap() map[K]V {
 	return res
 }
 
+// 按链表顺序遍历，遍历时只能删除当前键
+func (o LinkedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := o.l.root.next; p != o.l.root; {
+			nex := p.next
+			if !yield(p.k, p.v) {
+				return
+			}
+			p = nex
+		}
+	}
+}
+func (o LinkedHashMap[K, V]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o LinkedHashMap[K, V]) Values() iter.Seq[V] { return seqValues(o.All()) }
+func (o LinkedHashMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := o.l.root.prev; p != o.l.root; {
+			pre := p.prev
+			if !yield(p.k, p.v) {
+				return
+			}
+			p = pre
+		}
+	}
+}
+
 // 基本操作
 func (o LinkedHashMap[K, V]) Has(k K) bool { _, ok := o.m[k]; return ok }
 func (o LinkedHashMap[K, V]) Set(k K, v V) LinkedHashMap[K, V] {
@@ -304,10 +338,23 @@ func (o LinkedHashMap[K, V]) Get(k K) V {
 	if !o.Has(k) && o.factory != nil {
 		o.Set(k, o.factory())
 	}
-	node := o.m[k]
+	node, ok := o.m[k]
+	if !ok {
+		return *new(V)
+	}
 	o.afterAccess(node)
 	return node.v
 }
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数，在访问顺序模式下会移动节点
+func (o LinkedHashMap[K, V]) Lookup(k K) (V, bool) {
+	node, ok := o.m[k]
+	if !ok {
+		return *new(V), false
+	}
+	o.afterAccess(node)
+	return node.v, true
+}
 func (o LinkedHashMap[K, V]) Del(k K) LinkedHashMap[K, V] {
 	node := o.m[k]
 	delete(o.m, k)
@@ -359,17 +406,9 @@ func (o LinkedHashMap[K, V]) ReplaceFunc(f func(K, V) V) LinkedHashMap[K, V] {
 	return o.ForEach(func(k K, v V) { o.Set(k, f(k, v)) })
 }
 
-// 键值查询
-func (o LinkedHashMap[K, V]) Keys() []K {
-	res := make([]K, 0, o.Len())
-	o.ForEach(func(k K, v V) { res = append(res, k) })
-	return res
-}
-func (o LinkedHashMap[K, V]) Values() []V {
-	res := make([]V, 0, o.Len())
-	o.ForEach(func(k K, v V) { res = append(res, v) })
-	return res
-}
+// 键值列表查询
+func (o LinkedHashMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o LinkedHashMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
 
 // --------------------LinkedMapContainer接口--------------------
 func (o LinkedHashMap[K, V]) FirstKey() K {
@@ -405,6 +444,34 @@ func (o LinkedHashMap[K, V]) PrevKey(k K) K {
 	return node.prev.k
 }
 
+// 不会panic的链式操作，不存在时返回false
+func (o LinkedHashMap[K, V]) TryFirstKey() (K, bool) {
+	if o.Len() == 0 {
+		return *new(K), false
+	}
+	return o.l.front().k, true
+}
+func (o LinkedHashMap[K, V]) TryLastKey() (K, bool) {
+	if o.Len() == 0 {
+		return *new(K), false
+	}
+	return o.l.root.prev.k, true
+}
+func (o LinkedHashMap[K, V]) TryNextKey(k K) (K, bool) {
+	node, ok := o.m[k]
+	if !ok || node.next == o.l.root {
+		return *new(K), false
+	}
+	return node.next.k, true
+}
+func (o LinkedHashMap[K, V]) TryPrevKey(k K) (K, bool) {
+	node, ok := o.m[k]
+	if !ok || node.prev == o.l.root {
+		return *new(K), false
+	}
+	return node.prev.k, true
+}
+
 // ===================================链式哈希集===================================
 
 type LinkedHashSet[T comparable] struct {
@@ -441,11 +508,15 @@ func (o LinkedHashSet[T]) ForEach(f func(T)) LinkedHashSet[T] {
 	o.m.ForEach(func(k T, v struct{}) { f(k) })
 	return o
 }
-func (o LinkedHashSet[T]) ToSlice() []T { return o.m.Keys() }
+func (o LinkedHashSet[T]) ToSlice() []T { return o.m.KeySlice() }
 func (o LinkedHashSet[T]) String() string {
 	return "LinkedHashSet" + fmt.Sprint(o.ToSlice())
 }
 
+// 按链表顺序遍历，遍历时只能删除当前元素
+func (o LinkedHashSet[T]) All() iter.Seq[T]      { return o.m.Keys() }
+func (o LinkedHashSet[T]) Backward() iter.Seq[T] { return seqKeys(o.m.Backward()) }
+
 // --------------------SetContainer接口--------------------
 
 // 基本操作
@@ -512,6 +583,12 @@ func (o LinkedHashSet[T]) Last() T    { return o.m.LastKey() }
 func (o LinkedHashSet[T]) Next(k T) T { return o.m.NextKey(k) }
 func (o LinkedHashSet[T]) Prev(k T) T { return o.m.PrevKey(k) }
 
+// 不会panic的链式操作，不存在时返回false
+func (o LinkedHashSet[T]) TryFirst() (T, bool)   { return o.m.TryFirstKey() }
+func (o LinkedHashSet[T]) TryLast() (T, bool)    { return o.m.TryLastKey() }
+func (o LinkedHashSet[T]) TryNext(k T) (T, bool) { return o.m.TryNextKey(k) }
+func (o LinkedHashSet[T]) TryPrev(k T) (T, bool) { return o.m.TryPrevKey(k) }
+
 // ===================================多重哈希集===================================
 type MultiHashSet[T comparable] struct {
 	m     HashMap[T, int]
@@ -560,7 +637,11 @@ func (o MultiHashSet[T]) ToSlice() []T {
 func (o MultiHashSet[T]) String() string {
 	return strings.ReplaceAll(o.m.String(), "HashMap", "MultiHashSet")
 }
-func (o MultiHashSet[T]) Clear() MultiHashSet[T] { o.m.Clear(); return o }
+func (o MultiHashSet[T]) Clear() MultiHashSet[T] {
+	o.m.Clear()
+	*o.total = 0
+	return o
+}
 func (o MultiHashSet[T]) Clone() MultiHashSet[T] { return NewMultiHashSetFromSlice(o.ToSlice()) }
 
 // --------------------SetContainer接口--------------------
@@ -612,6 +693,12 @@ func (o MultiHashSet[T]) ToMap() map[T]int {
 	return res
 }
 
+// 遍历元素及其重数，顺序不确定
+func (o MultiHashSet[T]) All() iter.Seq2[T, int] { return o.m.All() }
+
+// 遍历所有元素，每个元素重复其重数次
+func (o MultiHashSet[T]) Values() iter.Seq[T] { return repeatKeys(o.All()) }
+
 // 计数操作
 func (o MultiHashSet[T]) AddN(x T, n int) MultiHashSet[T] {
 	if n <= 0 {
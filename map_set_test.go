@@ -2,6 +2,8 @@ package gods
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"testing"
 )
 
@@ -70,3 +72,57 @@ func Test多重哈希集(t *testing.T) {
 	}
 	fmt.Println(s)
 }
+
+func Test哈希表迭代器(t *testing.T) {
+	m := NewLinkedHashMap[int, string]()
+	for i := range 5 {
+		m.Set(i, fmt.Sprint(i))
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("LinkedHashMap.Keys = %v", got)
+	}
+	for k := range m.All() { // 遍历时删除当前键
+		if k%2 == 0 {
+			m.Del(k)
+		}
+	}
+	fmt.Println("删除偶数之后：", m)
+	fmt.Println("逆序遍历：", slices.Collect(seqKeys(m.Backward())))
+
+	h := NewHashMap[string, int]()
+	h.Set("a", 1).Set("b", 2)
+	fmt.Println("maps.Collect：", maps.Collect(h.All()))
+
+	s := NewMultiHashSetFromSlice([]int{1, 1, 2})
+	if got := len(slices.Collect(s.Values())); got != 3 {
+		t.Errorf("MultiHashSet.Values长度 = %v", got)
+	}
+}
+
+func Test映射Lookup(t *testing.T) {
+	hm := NewHashMap[string, int]().WithFactory(func() int { return 1 }).Set("zero", 0)
+	lm := NewLinkedHashMap[string, int]().Set("zero", 0)
+	for _, m := range []interface{ Lookup(string) (int, bool) }{hm, lm} {
+		v, ok := m.Lookup("zero")
+		_, missing := m.Lookup("none")
+		fmt.Println(v, ok, missing)
+		if v != 0 || !ok || missing {
+			t.Errorf("Lookup错误")
+		}
+	}
+	if hm.Has("none") {
+		t.Errorf("Lookup不应调用factory函数")
+	}
+
+	s := NewLinkedHashSet[int]().Add(1).Add(2)
+	next, ok := s.TryNext(1)
+	_, ok2 := s.TryNext(2)
+	_, ok3 := s.TryPrev(3)
+	fmt.Println(next, ok, ok2, ok3)
+	if next != 2 || !ok || ok2 || ok3 {
+		t.Errorf("链式集合Try操作错误")
+	}
+	if _, ok := NewLinkedHashSet[int]().TryFirst(); ok {
+		t.Errorf("空集合TryFirst应返回false")
+	}
+}
@@ -0,0 +1,210 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+)
+
+// ===================================单调队列===================================
+
+// 先进先出的滑动窗口，可以在O(1)时间内获取窗口中的最大值和最小值
+// 每个插入的元素按插入顺序获得一个从0开始的索引，可以按索引或条件从队首移除元素
+type MonotonicQueue[T any] struct {
+	window     Queue[T]
+	maxQ, minQ Queue[int] // 最大值和最小值候选元素的索引，对应的值分别单调不增和单调不减
+	head       *int       // 队首元素的索引
+	cmp        func(T, T) int
+}
+
+func NewMonotonicQueue[T cmp.Ordered]() MonotonicQueue[T] {
+	return NewMonotonicQueueFunc(cmp.Compare[T])
+}
+func NewMonotonicQueueFunc[T any](cmp func(T, T) int) MonotonicQueue[T] {
+	return MonotonicQueue[T]{window: NewQueue[T](), maxQ: NewQueue[int](), minQ: NewQueue[int](),
+		head: new(int), cmp: cmp}
+}
+
+// 索引为i的元素的值
+func (o MonotonicQueue[T]) at(i int) T { return o.window.Get(i - *o.head) }
+
+// --------------------Container接口--------------------
+func (o MonotonicQueue[T]) Len() int       { return o.window.Len() }
+func (o MonotonicQueue[T]) String() string { return "MonotonicQueue" + fmt.Sprint(o.ToSlice()) }
+func (o MonotonicQueue[T]) ToSlice() []T   { return o.window.ToSlice() }
+func (o MonotonicQueue[T]) Clear() MonotonicQueue[T] {
+	*o.head += o.Len()
+	o.window.Clear()
+	o.maxQ.Clear()
+	o.minQ.Clear()
+	return o
+}
+
+// 下一个插入的元素的索引，等于已插入的元素个数
+func (o MonotonicQueue[T]) NextIndex() int { return *o.head + o.Len() }
+
+// --------------------基本操作--------------------
+
+// 在队尾插入元素，均摊O(1)
+func (o MonotonicQueue[T]) Push(x T) MonotonicQueue[T] {
+	i := o.NextIndex()
+	o.maxQ.PopBackUntil(func(j int) bool { return o.cmp(o.at(j), x) >= 0 }).PushBack(i)
+	o.minQ.PopBackUntil(func(j int) bool { return o.cmp(o.at(j), x) <= 0 }).PushBack(i)
+	o.window.PushBack(x)
+	return o
+}
+
+// 弹出队首元素，队列为空时会panic
+func (o MonotonicQueue[T]) PopFront() T {
+	x := o.window.PopFront()
+	if o.maxQ.PeekFront() == *o.head {
+		o.maxQ.PopFront()
+	}
+	if o.minQ.PeekFront() == *o.head {
+		o.minQ.PopFront()
+	}
+	*o.head++
+	return x
+}
+
+// 移除索引小于i的元素，用于维持固定大小的窗口
+func (o MonotonicQueue[T]) EvictBefore(i int) MonotonicQueue[T] {
+	for o.Len() > 0 && *o.head < i {
+		o.PopFront()
+	}
+	return o
+}
+
+// 从队首开始移除满足条件的元素，直到遇到不满足条件的元素
+func (o MonotonicQueue[T]) EvictFunc(f func(T) bool) MonotonicQueue[T] {
+	for o.Len() > 0 && f(o.window.PeekFront()) {
+		o.PopFront()
+	}
+	return o
+}
+
+// 队首和队尾元素，队列为空时会panic
+func (o MonotonicQueue[T]) Front() T { return o.window.PeekFront() }
+func (o MonotonicQueue[T]) Back() T  { return o.window.PeekBack() }
+
+// 窗口中的最值，有多个最值时返回最早插入的，队列为空时会panic
+func (o MonotonicQueue[T]) Max() T { return o.at(o.maxQ.PeekFront()) }
+func (o MonotonicQueue[T]) Min() T { return o.at(o.minQ.PeekFront()) }
+
+// 队列为空时返回false，不会panic
+func (o MonotonicQueue[T]) TryMax() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Max(), true
+}
+func (o MonotonicQueue[T]) TryMin() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Min(), true
+}
+
+// ===================================单调栈===================================
+
+// 从栈底到栈顶单调不增的栈，插入元素时会先弹出所有严格小于它的元素
+// 因此栈底是所有元素中的最大值，栈顶是剩余元素中的最小值
+type MonotonicStack[T any] struct {
+	s    Stack[indexedValue[T]]
+	next *int // 下一个插入的元素的索引
+	cmp  func(T, T) int
+}
+
+type indexedValue[T any] struct {
+	i int
+	v T
+}
+
+func NewMonotonicStack[T cmp.Ordered]() MonotonicStack[T] {
+	return NewMonotonicStackFunc(cmp.Compare[T])
+}
+func NewMonotonicStackFunc[T any](cmp func(T, T) int) MonotonicStack[T] {
+	return MonotonicStack[T]{s: NewStack[indexedValue[T]](), next: new(int), cmp: cmp}
+}
+
+// --------------------Container接口--------------------
+func (o MonotonicStack[T]) Len() int       { return o.s.Len() }
+func (o MonotonicStack[T]) String() string { return "MonotonicStack" + fmt.Sprint(o.ToSlice()) }
+func (o MonotonicStack[T]) ToSlice() []T { // 从栈底到栈顶
+	res := make([]T, 0, o.Len())
+	for _, x := range o.s.GetSlice() {
+		res = append(res, x.v)
+	}
+	return res
+}
+func (o MonotonicStack[T]) Clear() MonotonicStack[T] { o.s.Clear(); return o }
+
+// --------------------基本操作--------------------
+
+// 插入元素，均摊O(1)
+func (o MonotonicStack[T]) Push(x T) MonotonicStack[T] { return o.PushFunc(x, nil) }
+
+// 插入元素，并对每个被弹出的元素调用f，参数为它的索引和值，x就是这些元素之后第一个比它们大的元素
+func (o MonotonicStack[T]) PushFunc(x T, f func(i int, v T)) MonotonicStack[T] {
+	for o.s.Len() > 0 && o.cmp(o.s.Peek().v, x) < 0 {
+		top := o.s.Pop()
+		if f != nil {
+			f(top.i, top.v)
+		}
+	}
+	o.s.Push(indexedValue[T]{*o.next, x})
+	*o.next++
+	return o
+}
+func (o MonotonicStack[T]) Pop() T { return o.s.Pop().v }
+
+// 栈顶元素和它的索引，栈为空时会panic
+func (o MonotonicStack[T]) Peek() T        { return o.s.Peek().v }
+func (o MonotonicStack[T]) PeekIndex() int { return o.s.Peek().i }
+
+// 栈中的最值，栈为空时会panic
+func (o MonotonicStack[T]) Max() T { return o.s.GetSlice()[0].v }
+func (o MonotonicStack[T]) Min() T { return o.Peek() }
+
+// 栈为空时返回false，不会panic
+func (o MonotonicStack[T]) TryPeek() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Peek(), true
+}
+
+// ===================================辅助函数===================================
+
+// 所有大小为k的窗口中的最大值和最小值，结果的长度为n-k+1
+func SlidingWindowMax[T cmp.Ordered](arr Array[T], k int) Array[T] {
+	return slidingWindow(arr, k, MonotonicQueue[T].Max)
+}
+func SlidingWindowMin[T cmp.Ordered](arr Array[T], k int) Array[T] {
+	return slidingWindow(arr, k, MonotonicQueue[T].Min)
+}
+func slidingWindow[T cmp.Ordered](arr Array[T], k int, get func(MonotonicQueue[T]) T) Array[T] {
+	if k <= 0 {
+		panic("窗口大小必须大于0：" + strconv.Itoa(k))
+	}
+	res := NewOrderedArray[T]()
+	q := NewMonotonicQueue[T]()
+	for i, x := range arr.All() {
+		q.Push(x).EvictBefore(i - k + 1)
+		if i >= k-1 {
+			res.Push(get(q))
+		}
+	}
+	return res
+}
+
+// 每个元素之后第一个严格大于它的元素的索引，不存在时为-1
+func NextGreater[T cmp.Ordered](arr Array[T]) Array[int] { return NextGreaterFunc(arr, cmp.Compare[T]) }
+func NextGreaterFunc[T any](arr Array[T], cmp func(T, T) int) Array[int] {
+	res := NewOrderedArray[int]().WithSlice(make([]int, arr.Len())).Fill(-1)
+	s := NewMonotonicStackFunc(cmp)
+	for i, x := range arr.All() {
+		s.PushFunc(x, func(j int, _ T) { res.Set(j, i) })
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test单调队列(t *testing.T) {
+	arr := NewArrayFromSlice([]int{1, 3, -1, -3, 5, 3, 6, 7})
+	mx, mn := SlidingWindowMax(arr, 3), SlidingWindowMin(arr, 3)
+	fmt.Println("窗口最大值：", mx)
+	fmt.Println("窗口最小值：", mn)
+	if !slices.Equal(mx.ToSlice(), []int{3, 3, 5, 5, 6, 7}) || !slices.Equal(mn.ToSlice(), []int{-1, -3, -3, -3, 3, 3}) {
+		t.Errorf("滑动窗口错误")
+	}
+	if !sameFunc(mx.cmp, NewOrderedArray[int]().cmp) || !sameFunc(mn.cmp, NewOrderedArray[int]().cmp) { // 结果不使用反射比较
+		t.Errorf("滑动窗口的结果应使用NewOrderedArray")
+	}
+
+	// 与暴力结果比较
+	q := NewMonotonicQueue[int]()
+	var window []int
+	for range 2000 {
+		switch r := rand.Intn(10); {
+		case r < 6:
+			x := rand.Intn(20)
+			q.Push(x)
+			window = append(window, x)
+		case r < 8:
+			i := q.NextIndex() - rand.Intn(5)
+			q.EvictBefore(i)
+			if n := len(window) - (q.NextIndex() - i); n > 0 {
+				window = window[min(n, len(window)):]
+			}
+		default:
+			limit := rand.Intn(20)
+			q.EvictFunc(func(x int) bool { return x < limit })
+			for len(window) > 0 && window[0] < limit {
+				window = window[1:]
+			}
+		}
+		if !slices.Equal(q.ToSlice(), window) {
+			t.Fatalf("窗口错误：%v %v", q, window)
+		}
+		x, ok := q.TryMax()
+		y, _ := q.TryMin()
+		if ok != (len(window) > 0) || ok && (x != slices.Max(window) || y != slices.Min(window)) {
+			t.Fatalf("最值错误：%v %v %v", q, x, y)
+		}
+	}
+	q.Clear()
+	if _, ok := q.TryMin(); ok || q.Len() != 0 {
+		t.Errorf("Clear错误")
+	}
+	if q.Push(1).Push(2).PopFront() != 1 || q.Max() != 2 || q.Front() != 2 {
+		t.Errorf("Clear后插入错误")
+	}
+
+	// 自定义比较函数，相同的最值返回最早插入的
+	type item struct {
+		name string
+		v    int
+	}
+	items := NewMonotonicQueueFunc(func(a, b item) int { return a.v - b.v })
+	items.Push(item{"a", 1}).Push(item{"b", 3}).Push(item{"c", 3}).Push(item{"d", 2})
+	if items.Max().name != "b" || items.Min().name != "a" {
+		t.Errorf("自定义比较函数错误：%v", items)
+	}
+}
+
+func Test单调栈(t *testing.T) {
+	arr := NewArrayFromSlice([]int{2, 1, 2, 4, 3})
+	next := NextGreater(arr)
+	fmt.Println("下一个更大元素的索引：", next)
+	if !slices.Equal(next.ToSlice(), []int{3, 2, 3, -1, -1}) {
+		t.Errorf("NextGreater错误")
+	}
+	if !sameFunc(next.cmp, NewOrderedArray[int]().cmp) {
+		t.Errorf("NextGreater的结果应使用NewOrderedArray")
+	}
+
+	s := NewMonotonicStack[int]()
+	var popped []int
+	for _, x := range []int{5, 3, 3, 1, 4} {
+		s.PushFunc(x, func(i, v int) { popped = append(popped, i) })
+	}
+	fmt.Println(s, "弹出的索引：", popped)
+	if !slices.Equal(s.ToSlice(), []int{5, 4}) || !slices.Equal(popped, []int{3, 2, 1}) {
+		t.Errorf("PushFunc错误")
+	}
+	if s.Max() != 5 || s.Min() != 4 || s.PeekIndex() != 4 {
+		t.Errorf("最值错误")
+	}
+	if s.Pop() != 4 || s.Pop() != 5 {
+		t.Errorf("Pop错误")
+	}
+	if _, ok := s.TryPeek(); ok {
+		t.Errorf("空栈TryPeek应返回false")
+	}
+	s.Push(1).Clear()
+	if s.Len() != 0 {
+		t.Errorf("Clear错误")
+	}
+}
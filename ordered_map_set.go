@@ -3,19 +3,20 @@ package gods
 import (
 	"cmp"
 	"fmt"
-	"maps"
+	"iter"
+	"slices"
 	"strings"
 )
 
 // ===================================键值对记录===================================
 
-type MapEntry[K cmp.Ordered, V any] struct {
+type MapEntry[K comparable, V any] struct {
 	node *avlNode[K, V]
 	K    K
 	V    V
 }
 
-func newEntryFromNode[K cmp.Ordered, V any](node *avlNode[K, V]) *MapEntry[K, V] {
+func newEntryFromNode[K comparable, V any](node *avlNode[K, V]) *MapEntry[K, V] {
 	if node == nil {
 		return nil
 	}
@@ -26,16 +27,37 @@ func newEntryFromNode[K cmp.Ordered, V any](node *avlNode[K, V]) *MapEntry[K, V]
 	}
 }
 
-// 左右节点对应的Entry
-func (o *MapEntry[K, V]) Left() *MapEntry[K, V]  { return newEntryFromNode(o.node.left) }
-func (o *MapEntry[K, V]) Right() *MapEntry[K, V] { return newEntryFromNode(o.node.right) }
+// 左右节点对应的Entry，BTreeMap返回的Entry没有左右节点，返回nil
+func (o *MapEntry[K, V]) Left() *MapEntry[K, V] {
+	if o.node == nil {
+		return nil
+	}
+	return newEntryFromNode(o.node.left)
+}
+func (o *MapEntry[K, V]) Right() *MapEntry[K, V] {
+	if o.node == nil {
+		return nil
+	}
+	return newEntryFromNode(o.node.right)
+}
+
+// 返回Entry的键，Entry为nil时返回false
+func (o *MapEntry[K, V]) key() (K, bool) {
+	if o == nil {
+		return *new(K), false
+	}
+	return o.K, true
+}
 
 // ===================================监视节点变化的函数===================================
-type Watcher[K cmp.Ordered, V any] func(cur, left, right *MapEntry[K, V]) // 用于额外记录和统计节点的信息，比如求和
+type Watcher[K comparable, V any] func(cur, left, right *MapEntry[K, V]) // 用于额外记录和统计节点的信息，比如求和
+
+// 节点的高度和大小更新后调用的内部钩子，Watcher会被转换成钩子，增强映射直接使用钩子更新节点中的聚合值
+type nodeHook[K comparable, V any] func(node *avlNode[K, V])
 
 // ===================================AVL树节点===================================
 
-type avlNode[K cmp.Ordered, V any] struct {
+type avlNode[K comparable, V any] struct {
 	k           K
 	v           V
 	left, right *avlNode[K, V]
@@ -43,7 +65,7 @@ type avlNode[K cmp.Ordered, V any] struct {
 	size        int // 子树节点个数
 }
 
-func newAVLNode[K cmp.Ordered, V any](k K, v V) *avlNode[K, V] {
+func newAVLNode[K comparable, V any](k K, v V) *avlNode[K, V] {
 	return &avlNode[K, V]{
 		k:     k,
 		v:     v,
@@ -62,7 +84,7 @@ func (o avlNode[K, V]) factor() int {
 	}
 	return rd - ld
 }
-func (o *avlNode[K, V]) updateStatus(w Watcher[K, V]) *avlNode[K, V] {
+func (o *avlNode[K, V]) updateStatus(w nodeHook[K, V]) *avlNode[K, V] {
 	ld, rd := 0, 0
 	if o.left != nil {
 		ld = o.left.depth
@@ -80,11 +102,11 @@ func (o *avlNode[K, V]) updateStatus(w Watcher[K, V]) *avlNode[K, V] {
 	o.depth = max(ld, rd) + 1
 	o.size = lSize + rSize + 1
 	if w != nil {
-		w(newEntryFromNode(o), newEntryFromNode(o.left), newEntryFromNode(o.right))
+		w(o)
 	}
 	return o
 }
-func (o *avlNode[K, V]) rotateLeft(w Watcher[K, V]) *avlNode[K, V] {
+func (o *avlNode[K, V]) rotateLeft(w nodeHook[K, V]) *avlNode[K, V] {
 	r := o.right
 	rl := r.left
 
@@ -94,7 +116,7 @@ func (o *avlNode[K, V]) rotateLeft(w Watcher[K, V]) *avlNode[K, V] {
 	r.updateStatus(w)
 	return r
 }
-func (o *avlNode[K, V]) rotateRight(w Watcher[K, V]) *avlNode[K, V] {
+func (o *avlNode[K, V]) rotateRight(w nodeHook[K, V]) *avlNode[K, V] {
 	l := o.left
 	lr := l.right
 
@@ -104,7 +126,7 @@ func (o *avlNode[K, V]) rotateRight(w Watcher[K, V]) *avlNode[K, V] {
 	l.updateStatus(w)
 	return l
 }
-func (o *avlNode[K, V]) balance(w Watcher[K, V]) *avlNode[K, V] {
+func (o *avlNode[K, V]) balance(w nodeHook[K, V]) *avlNode[K, V] {
 	if o.factor() == -2 {
 		if o.left.factor() > 0 { // LR
 			o.left = o.left.rotateLeft(w)
@@ -119,15 +141,116 @@ func (o *avlNode[K, V]) balance(w Watcher[K, V]) *avlNode[K, V] {
 	return o
 }
 
+// 复制单个节点
+func (o *avlNode[K, V]) copy() *avlNode[K, V] {
+	res := *o
+	return &res
+}
+
+// 复制整棵子树，代价为O(n)
+func (o *avlNode[K, V]) deepCopy() *avlNode[K, V] {
+	if o == nil {
+		return nil
+	}
+	res := o.copy()
+	res.left, res.right = o.left.deepCopy(), o.right.deepCopy()
+	return res
+}
+
+// 中序遍历子树，使用显式栈，可以提前终止
+func (o *avlNode[K, V]) ascend() iter.Seq[*avlNode[K, V]] {
+	return o.ascendWhere(func(*avlNode[K, V]) bool { return true })
+}
+
+// 逆中序遍历子树
+func (o *avlNode[K, V]) descend() iter.Seq[*avlNode[K, V]] {
+	return o.descendWhere(func(*avlNode[K, V]) bool { return true })
+}
+
+// 从第一个满足inRange的节点开始中序遍历，要求inRange在左侧返回false，右侧返回true
+// 初始化只需沿一条路径向下，代价为O(logn+遍历个数)
+func (o *avlNode[K, V]) ascendWhere(inRange func(*avlNode[K, V]) bool) iter.Seq[*avlNode[K, V]] {
+	return func(yield func(*avlNode[K, V]) bool) {
+		var stack []*avlNode[K, V]
+		for node := o; node != nil; {
+			if inRange(node) {
+				stack = append(stack, node)
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node) {
+				return
+			}
+			for p := node.right; p != nil; p = p.left {
+				stack = append(stack, p)
+			}
+		}
+	}
+}
+
+// 从最后一个满足inRange的节点开始逆中序遍历，要求inRange在左侧返回true，右侧返回false
+func (o *avlNode[K, V]) descendWhere(inRange func(*avlNode[K, V]) bool) iter.Seq[*avlNode[K, V]] {
+	return func(yield func(*avlNode[K, V]) bool) {
+		var stack []*avlNode[K, V]
+		for node := o; node != nil; {
+			if inRange(node) {
+				stack = append(stack, node)
+				node = node.right
+			} else {
+				node = node.left
+			}
+		}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node) {
+				return
+			}
+			for p := node.left; p != nil; p = p.right {
+				stack = append(stack, p)
+			}
+		}
+	}
+}
+
 // ===================================基于AVL树的有序映射===================================
-type TreeMap[K cmp.Ordered, V any] struct {
+type TreeMap[K comparable, V any] struct {
 	dummyRoot *avlNode[K, V] // 实际的根节点为dummyRoot.right
+	cmp       func(K, K) int // 键的比较函数
 	factory   func() V
-	w         Watcher[K, V]
+	w         nodeHook[K, V]
+	order     *int // 比较函数的标识，标识相同的映射键顺序一致，集合运算可以直接拆分合并
 }
 
+var naturalOrder = new(int) // 所有按自然顺序排序的映射共享的标识
+
+// 创建按自然顺序排序的映射
 func NewTreeMap[K cmp.Ordered, V any]() TreeMap[K, V] {
-	return TreeMap[K, V]{dummyRoot: newAVLNode(*new(K), *new(V))}
+	o := NewTreeMapFunc[K, V](cmp.Compare[K])
+	o.order = naturalOrder
+	return o
+}
+
+// 创建使用自定义比较函数排序的映射，cmp返回负数、0、正数分别表示小于、等于、大于
+// 每次调用都会得到新的比较函数标识，只有从同一个映射复制或派生的映射之间才能直接拆分合并
+func NewTreeMapFunc[K comparable, V any](cmp func(K, K) int) TreeMap[K, V] {
+	return TreeMap[K, V]{dummyRoot: newAVLNode(*new(K), *new(V)), cmp: cmp, order: new(int)}
+}
+
+// 解码零值映射时使用的自然排序映射，和NewTreeMap创建的映射共享比较函数的标识
+func newNaturalTreeMap[K comparable, V any]() (TreeMap[K, V], error) {
+	c, err := naturalCmp[K]()
+	if err != nil {
+		return TreeMap[K, V]{}, err
+	}
+	o := NewTreeMapFunc[K, V](c)
+	o.order = naturalOrder
+	return o, nil
 }
 func NewTreeMapFromMap[K cmp.Ordered, V any](m map[K]V) TreeMap[K, V] {
 	o := NewTreeMap[K, V]()
@@ -148,7 +271,13 @@ func (o TreeMap[K, V]) WithWatcher(w Watcher[K, V]) TreeMap[K, V] {
 	if o.Len() != 0 {
 		panic("映射必须空，才能设置Watcher")
 	}
-	o.w = w
+	if w == nil { // 移除Watcher
+		o.w = nil
+		return o
+	}
+	o.w = func(node *avlNode[K, V]) {
+		w(newEntryFromNode(node), newEntryFromNode(node.left), newEntryFromNode(node.right))
+	}
 	return o
 }
 
@@ -156,7 +285,12 @@ func (o TreeMap[K, V]) GetRoot() *MapEntry[K, V] {
 	return newEntryFromNode(o.dummyRoot.right)
 }
 
-func (o TreeMap[K, V]) root() *avlNode[K, V]                      { return o.dummyRoot.right }
+func (o TreeMap[K, V]) root() *avlNode[K, V] {
+	if o.dummyRoot == nil { // 零值映射
+		return nil
+	}
+	return o.dummyRoot.right
+}
 func (o TreeMap[K, V]) setRoot(root *avlNode[K, V]) TreeMap[K, V] { o.dummyRoot.right = root; return o }
 
 // ==============Container接口============= */
@@ -166,12 +300,44 @@ func (o TreeMap[K, V]) Len() int {
 	}
 	return o.root().size
 }
-func (o TreeMap[K, V]) String() string       { return "TreeMap" + fmt.Sprint(o.ToMap())[3:] }
+func (o TreeMap[K, V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "TreeMap[" + strings.Join(entries, " ") + "]"
+}
 func (o TreeMap[K, V]) Clear() TreeMap[K, V] { o.setRoot(nil); return o }
-func (o TreeMap[K, V]) Clone() TreeMap[K, V] {
-	clone := NewTreeMap[K, V]()
-	o.ForEach(func(k K, v V) { clone.Set(k, v) })
-	return clone
+func (o TreeMap[K, V]) Clone() TreeMap[K, V] { // 直接复制树结构，代价为O(n)
+	return o.empty().setRoot(o.root().deepCopy())
+}
+
+// 使用严格升序的键值列表在O(n)时间内重建整棵树，不满足升序时返回false且不修改映射
+func (o TreeMap[K, V]) setSorted(keys []K, values []V) bool {
+	for i := 1; i < len(keys); i++ {
+		if o.cmp(keys[i-1], keys[i]) >= 0 {
+			return false
+		}
+	}
+	var build func(l, r int) *avlNode[K, V]
+	build = func(l, r int) *avlNode[K, V] {
+		if l >= r {
+			return nil
+		}
+		m := (l + r) / 2
+		node := newAVLNode(keys[m], values[m])
+		node.left, node.right = build(l, m), build(m+1, r)
+		return node.updateStatus(o.w)
+	}
+	o.setRoot(build(0, len(keys)))
+	return true
+}
+
+// 创建具有相同比较函数的空映射
+func (o TreeMap[K, V]) empty() TreeMap[K, V] {
+	res := NewTreeMapFunc[K, V](o.cmp)
+	res.order = o.order
+	return res
 }
 
 // ==============MapContainer接口=============
@@ -199,6 +365,53 @@ func (o TreeMap[K, V]) ToMap() map[K]V {
 	return m
 }
 
+// 按键升序惰性遍历，遍历过程中不能修改映射，需要修改时使用ForEach
+func (o TreeMap[K, V]) All() iter.Seq2[K, V] { return nodeEntries(o.root().ascend()) }
+func (o TreeMap[K, V]) Keys() iter.Seq[K]    { return seqKeys(o.All()) }
+func (o TreeMap[K, V]) Values() iter.Seq[V]  { return seqValues(o.All()) }
+
+// 按键降序惰性遍历
+func (o TreeMap[K, V]) Backward() iter.Seq2[K, V] { return nodeEntries(o.root().descend()) }
+
+// 从第一个>=k的键开始升序惰性遍历
+func (o TreeMap[K, V]) AscendFrom(k K) iter.Seq2[K, V] {
+	return nodeEntries(o.root().ascendWhere(func(node *avlNode[K, V]) bool { return o.cmp(node.k, k) >= 0 }))
+}
+
+// 从最后一个<=k的键开始降序惰性遍历
+func (o TreeMap[K, V]) DescendFrom(k K) iter.Seq2[K, V] {
+	return nodeEntries(o.root().descendWhere(func(node *avlNode[K, V]) bool { return o.cmp(node.k, k) <= 0 }))
+}
+
+// 升序惰性遍历lo到hi之间的键，由loInclusive和hiInclusive决定是否包含边界
+func (o TreeMap[K, V]) Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		nodes := o.root().ascendWhere(func(node *avlNode[K, V]) bool {
+			c := o.cmp(node.k, lo)
+			return c > 0 || loInclusive && c == 0
+		})
+		for node := range nodes {
+			if c := o.cmp(node.k, hi); c > 0 || !hiInclusive && c == 0 {
+				return
+			}
+			if !yield(node.k, node.v) {
+				return
+			}
+		}
+	}
+}
+
+// 将节点迭代器转换成键值对迭代器
+func nodeEntries[K comparable, V any](nodes iter.Seq[*avlNode[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := range nodes {
+			if !yield(node.k, node.v) {
+				return
+			}
+		}
+	}
+}
+
 // 基本操作
 func (o TreeMap[K, V]) Get(k K) V {
 	node := o.getNode(k)
@@ -212,14 +425,22 @@ func (o TreeMap[K, V]) Get(k K) V {
 	}
 	return *new(V)
 }
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o TreeMap[K, V]) Lookup(k K) (V, bool) {
+	if node := o.getNode(k); node != nil {
+		return node.v, true
+	}
+	return *new(V), false
+}
 func (o TreeMap[K, V]) Set(k K, v V) TreeMap[K, V] {
 	var _set func(node *avlNode[K, V], k K, v V) *avlNode[K, V]
 	_set = func(node *avlNode[K, V], k K, v V) *avlNode[K, V] {
 		if node == nil {
 			return newAVLNode(k, v).updateStatus(o.w)
-		} else if k < node.k {
+		} else if c := o.cmp(k, node.k); c < 0 {
 			node.left = _set(node.left, k, v)
-		} else if k > node.k {
+		} else if c > 0 {
 			node.right = _set(node.right, k, v)
 		} else {
 			node.v = v
@@ -236,9 +457,9 @@ func (o TreeMap[K, V]) Del(k K) TreeMap[K, V] {
 		if node == nil {
 			return nil
 		}
-		if k < node.k {
+		if c := o.cmp(k, node.k); c < 0 {
 			node.left = _del(node.left, k)
-		} else if k > node.k {
+		} else if c > 0 {
 			node.right = _del(node.right, k)
 		} else if node.right == nil { // 只有左子树或者右子树为零，才会真正发生删除
 			return node.left
@@ -264,9 +485,9 @@ func (o TreeMap[K, V]) Has(k K) bool { return o.getNode(k) != nil }
 func (o TreeMap[K, V]) getNode(k K) *avlNode[K, V] {
 	node := o.root()
 	for node != nil {
-		if k < node.k {
+		if c := o.cmp(k, node.k); c < 0 {
 			node = node.left
-		} else if k > node.k {
+		} else if c > 0 {
 			node = node.right
 		} else {
 			return node
@@ -289,9 +510,8 @@ func (o TreeMap[K, V]) GetOrSet(k K, v V) V {
 	o.Set(k, v)
 	return v
 }
-func (o TreeMap[K, V]) Extend(other TreeMap[K, V]) TreeMap[K, V] {
-	other.ForEach(func(k K, v V) { o.Set(k, v) })
-	return o
+func (o TreeMap[K, V]) Extend(other TreeMap[K, V]) TreeMap[K, V] { // 基于拆分与合并，键相同时使用other的值
+	return o.setOp(other, o.union, func() { other.ForEach(func(k K, v V) { o.Set(k, v) }) })
 }
 func (o TreeMap[K, V]) DelFunc(f func(K, V) bool) TreeMap[K, V] {
 	return o.ForEach(func(k K, v V) {
@@ -304,17 +524,9 @@ func (o TreeMap[K, V]) ReplaceFunc(f func(K, V) V) TreeMap[K, V] {
 	return o.ForEach(func(k K, v V) { o.Set(k, f(k, v)) })
 }
 
-// 键值查询
-func (o TreeMap[K, V]) Keys() []K {
-	var keys []K
-	o.ForEach(func(k K, v V) { keys = append(keys, k) })
-	return keys
-}
-func (o TreeMap[K, V]) Values() []V {
-	var values []V
-	o.ForEach(func(k K, v V) { values = append(values, v) })
-	return values
-}
+// 键值列表查询
+func (o TreeMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o TreeMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
 
 // ==============TreeMapContainer接口=============
 // 二分查找键值对
@@ -345,7 +557,7 @@ func (o TreeMap[K, V]) Lower(k K) *MapEntry[K, V] {
 		if node == nil {
 			return
 		}
-		if node.k < k {
+		if o.cmp(node.k, k) < 0 {
 			res = node
 			_lowerKey(node.right, k) // 寻找更大的键，满足<k
 		} else {
@@ -363,7 +575,7 @@ func (o TreeMap[K, V]) Higher(k K) *MapEntry[K, V] {
 		if node == nil {
 			return
 		}
-		if node.k > k {
+		if o.cmp(node.k, k) > 0 {
 			res = node
 			_higherKey(node.left, k) // 寻找更小的键，满足>k
 		} else {
@@ -381,7 +593,7 @@ func (o TreeMap[K, V]) Floor(k K) *MapEntry[K, V] {
 		if node == nil {
 			return
 		}
-		if node.k <= k {
+		if o.cmp(node.k, k) <= 0 {
 			res = node
 			_floorKey(node.right, k) // 寻找更大的键，满足<=k
 		} else {
@@ -399,7 +611,7 @@ func (o TreeMap[K, V]) Ceiling(k K) *MapEntry[K, V] {
 		if node == nil {
 			return
 		}
-		if node.k >= k {
+		if o.cmp(node.k, k) >= 0 {
 			res = node
 			_ceilingKey(node.left, k) // 寻找更小的键，满足>=k
 		} else {
@@ -436,9 +648,9 @@ func (o TreeMap[K, V]) Rank(k K) int { // 返回小于等于k的节点个数
 		if node == nil {
 			return 0
 		}
-		if k == node.k {
+		if c := o.cmp(k, node.k); c == 0 {
 			return o.size(node.left) + 1
-		} else if k < node.k {
+		} else if c < 0 {
 			return _rank(node.left) // 当小于所有节点，返回0
 		} else {
 			return o.size(node.left) + 1 + _rank(node.right) // 当大于所有节点，会返回n
@@ -457,7 +669,7 @@ func (o TreeMap[K, V]) size(node *avlNode[K, V]) int {
 
 // 子映射截取
 func (o TreeMap[K, V]) HeadMap(n int) TreeMap[K, V] {
-	newMap := NewTreeMap[K, V]()
+	newMap := o.empty()
 	var _headMap func(node *avlNode[K, V])
 	_headMap = func(node *avlNode[K, V]) {
 		if node == nil {
@@ -476,7 +688,7 @@ func (o TreeMap[K, V]) HeadMap(n int) TreeMap[K, V] {
 	return newMap
 }
 func (o TreeMap[K, V]) TailMap(n int) TreeMap[K, V] {
-	newMap := NewTreeMap[K, V]()
+	newMap := o.empty()
 	var _tailMap func(node *avlNode[K, V])
 	_tailMap = func(node *avlNode[K, V]) {
 		if node == nil {
@@ -495,6 +707,235 @@ func (o TreeMap[K, V]) TailMap(n int) TreeMap[K, V] {
 	return newMap
 }
 
+// --------------------按键的范围操作--------------------
+// 边界的开闭与Java的NavigableMap相同，由loInclusive和hiInclusive决定是否包含边界
+
+// 小于k的键的个数，inclusive为true时统计小于等于k的键，O(logn)
+func (o TreeMap[K, V]) countBelow(k K, inclusive bool) int {
+	cnt := 0
+	for node := o.root(); node != nil; {
+		if c := o.cmp(node.k, k); c < 0 || inclusive && c == 0 {
+			cnt += o.size(node.left) + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return cnt
+}
+
+// lo到hi之间的键的个数，O(logn)
+func (o TreeMap[K, V]) CountRange(lo, hi K, loInclusive, hiInclusive bool) int {
+	return o.SubMap(lo, hi, loInclusive, hiInclusive).Len()
+}
+
+// 删除lo到hi之间的键，基于拆分与合并，O(logn)
+func (o TreeMap[K, V]) DelRange(lo, hi K, loInclusive, hiInclusive bool) TreeMap[K, V] {
+	o.SubMap(lo, hi, loInclusive, hiInclusive).Clear()
+	return o
+}
+
+// lo到hi之间的键组成的视图，不复制节点，O(1)，需要独立的映射时使用视图的ToTreeMap
+func (o TreeMap[K, V]) SubMap(lo, hi K, loInclusive, hiInclusive bool) TreeMapView[K, V] {
+	return TreeMapView[K, V]{m: o, lo: viewBound[K]{lo, loInclusive, true}, hi: viewBound[K]{hi, hiInclusive, true}}
+}
+
+// 小于hi的键组成的视图，inclusive为true时包含hi，O(1)
+func (o TreeMap[K, V]) HeadMapByKey(hi K, inclusive bool) TreeMapView[K, V] {
+	return TreeMapView[K, V]{m: o, hi: viewBound[K]{hi, inclusive, true}}
+}
+
+// 大于lo的键组成的视图，inclusive为true时包含lo，O(1)
+func (o TreeMap[K, V]) TailMapByKey(lo K, inclusive bool) TreeMapView[K, V] {
+	return TreeMapView[K, V]{m: o, lo: viewBound[K]{lo, inclusive, true}}
+}
+
+// 使用升序的键值对创建具有相同比较函数、factory和Watcher的映射，O(k)
+func (o TreeMap[K, V]) fromSorted(seq iter.Seq2[K, V]) TreeMap[K, V] {
+	var keys []K
+	var values []V
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	res := o.emptyLike()
+	res.setSorted(keys, values)
+	return res
+}
+
+// ==============拆分与合并=============
+
+// 按k拆分映射，o保留小于k的部分，返回大于等于k的部分，O(logn)
+func (o TreeMap[K, V]) Split(k K) TreeMap[K, V] {
+	l, r := o.splitAt(o.root(), k, false)
+	o.setRoot(l)
+	return o.emptyLike().setRoot(r)
+}
+
+// 将other的所有键值对移动到o中，要求o的键都小于other的键，否则会panic，other会被清空，O(logn)
+func (o TreeMap[K, V]) Join(other TreeMap[K, V]) TreeMap[K, V] {
+	if o.dummyRoot == other.dummyRoot || other.Len() == 0 {
+		return o
+	}
+	if o.Len() > 0 && o.cmp(o.Last().K, other.First().K) >= 0 {
+		panic("Join要求左侧映射的键都小于右侧映射的键")
+	}
+	o.setRoot(o.join2(o.root(), other.root()))
+	other.setRoot(nil)
+	return o
+}
+
+// 创建具有相同比较函数、factory和Watcher的空映射
+func (o TreeMap[K, V]) emptyLike() TreeMap[K, V] {
+	res := o.empty()
+	res.factory, res.w = o.factory, o.w
+	return res
+}
+
+// 辅助函数，返回节点高度
+func (o TreeMap[K, V]) depth(node *avlNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.depth
+}
+
+// 以mid为中间节点合并两棵树，要求l的键都小于mid的键，r的键都大于mid的键，O(|h(l)-h(r)|)
+func (o TreeMap[K, V]) join(l, mid, r *avlNode[K, V]) *avlNode[K, V] {
+	if ld, rd := o.depth(l), o.depth(r); ld > rd+1 { // 沿l的右侧向下找到高度接近的子树
+		l.right = o.join(l.right, mid, r)
+		return l.updateStatus(o.w).balance(o.w)
+	} else if rd > ld+1 {
+		r.left = o.join(l, mid, r.left)
+		return r.updateStatus(o.w).balance(o.w)
+	}
+	mid.left, mid.right = l, r
+	return mid.updateStatus(o.w)
+}
+
+// 合并两棵树，要求l的键都小于r的键
+func (o TreeMap[K, V]) join2(l, r *avlNode[K, V]) *avlNode[K, V] {
+	if l == nil {
+		return r
+	}
+	l, last := o.splitLast(l)
+	return o.join(l, last, r)
+}
+
+// 移除并返回最大的节点
+func (o TreeMap[K, V]) splitLast(node *avlNode[K, V]) (*avlNode[K, V], *avlNode[K, V]) {
+	if node.right == nil {
+		l := node.left
+		node.left = nil
+		return l, node.updateStatus(o.w)
+	}
+	var last *avlNode[K, V]
+	node.right, last = o.splitLast(node.right)
+	return node.updateStatus(o.w).balance(o.w), last
+}
+
+// 按k将树拆分为两部分，键等于k的节点在inclusive为true时放入左侧，否则放入右侧
+func (o TreeMap[K, V]) splitAt(node *avlNode[K, V], k K, inclusive bool) (l, r *avlNode[K, V]) {
+	l, mid, r := o.split(node, k)
+	if mid == nil {
+		return l, r
+	} else if inclusive {
+		return o.join(l, mid, nil), r
+	}
+	return l, o.join(nil, mid, r)
+}
+
+// 按k将树拆分为小于k的部分、键等于k的节点和大于k的部分，会修改原来的树
+func (o TreeMap[K, V]) split(node *avlNode[K, V], k K) (l, mid, r *avlNode[K, V]) {
+	if node == nil {
+		return nil, nil, nil
+	}
+	if c := o.cmp(k, node.k); c < 0 {
+		l, mid, r = o.split(node.left, k)
+		return l, mid, o.join(r, node, node.right)
+	} else if c > 0 {
+		l, mid, r = o.split(node.right, k)
+		return o.join(node.left, node, l), mid, r
+	}
+	l, r = node.left, node.right
+	node.left, node.right = nil, nil
+	return l, node.updateStatus(o.w), r
+}
+
+// --------------------基于拆分与合并的集合运算--------------------
+// 以下函数中a属于o，可以修改，b属于其他映射，只能读取，需要放入结果的节点会被复制
+// 对b的每个节点拆分a，代价为O(m*log(n/m+1))，m和n分别为较小和较大的树的大小
+
+// 并集，键相同时使用b的值
+func (o TreeMap[K, V]) union(a, b *avlNode[K, V]) *avlNode[K, V] {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return o.copyTree(b)
+	}
+	l, _, r := o.split(a, b.k)
+	l, r = o.union(l, b.left), o.union(r, b.right)
+	return o.join(l, b.copy(), r)
+}
+func (o TreeMap[K, V]) intersect(a, b *avlNode[K, V]) *avlNode[K, V] {
+	if a == nil || b == nil {
+		return nil
+	}
+	l, mid, r := o.split(a, b.k)
+	l, r = o.intersect(l, b.left), o.intersect(r, b.right)
+	if mid != nil {
+		return o.join(l, mid, r)
+	}
+	return o.join2(l, r)
+}
+func (o TreeMap[K, V]) difference(a, b *avlNode[K, V]) *avlNode[K, V] {
+	if a == nil || b == nil {
+		return a
+	}
+	l, _, r := o.split(a, b.k)
+	return o.join2(o.difference(l, b.left), o.difference(r, b.right))
+}
+func (o TreeMap[K, V]) symmetricDifference(a, b *avlNode[K, V]) *avlNode[K, V] {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return o.copyTree(b)
+	}
+	l, mid, r := o.split(a, b.k)
+	l, r = o.symmetricDifference(l, b.left), o.symmetricDifference(r, b.right)
+	if mid != nil {
+		return o.join2(l, r)
+	}
+	return o.join(l, b.copy(), r)
+}
+
+// 复制其他映射的子树，并使用o的Watcher更新节点状态
+func (o TreeMap[K, V]) copyTree(node *avlNode[K, V]) *avlNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	res := node.copy()
+	res.left, res.right = o.copyTree(node.left), o.copyTree(node.right)
+	return res.updateStatus(o.w)
+}
+
+// 对o和other的树执行集合运算，other为o自身时先复制一份
+// 比较函数的标识不同时，other的键按o的比较函数不一定有序，不能直接拆分合并，改为调用fallback逐个处理
+func (o TreeMap[K, V]) setOp(other TreeMap[K, V], op func(a, b *avlNode[K, V]) *avlNode[K, V], fallback func()) TreeMap[K, V] {
+	if o.dummyRoot == other.dummyRoot {
+		other = other.Clone()
+	} else if !o.sameOrder(other) {
+		fallback()
+		return o
+	}
+	return o.setRoot(op(o.root(), other.root()))
+}
+
+// 两个映射的键顺序是否一致，只比较标识，O(1)
+func (o TreeMap[K, V]) sameOrder(other TreeMap[K, V]) bool { return o.order == other.order }
+
 // ==============其他============= */
 func (o TreeMap[K, V]) PrintTree() {
 	var _print func(node *avlNode[K, V], depth int)
@@ -522,12 +963,15 @@ func (o TreeMap[K, V]) PrintTree() {
 
 // ===================================有序集===================================
 
-type TreeSet[T cmp.Ordered] struct {
+type TreeSet[T comparable] struct {
 	m TreeMap[T, struct{}]
 }
 
-func NewTreeSet[T cmp.Ordered]() TreeSet[T] {
-	return TreeSet[T]{m: NewTreeMap[T, struct{}]()}
+func NewTreeSet[T cmp.Ordered]() TreeSet[T] { return TreeSet[T]{m: NewTreeMap[T, struct{}]()} }
+
+// 创建使用自定义比较函数排序的集合
+func NewTreeSetFunc[T comparable](cmp func(T, T) int) TreeSet[T] {
+	return TreeSet[T]{m: NewTreeMapFunc[T, struct{}](cmp)}
 }
 
 func NewTreeSetFromSlice[T cmp.Ordered](slice []T) TreeSet[T] {
@@ -543,11 +987,23 @@ func (o TreeSet[T]) Len() int          { return o.m.Len() }
 func (o TreeSet[T]) String() string    { return "TreeSet" + fmt.Sprint(o.ToSlice()) }
 func (o TreeSet[T]) Clear() TreeSet[T] { o.m.Clear(); return o }
 func (o TreeSet[T]) Clone() TreeSet[T] { o.m = o.m.Clone(); return o }
+func (o TreeSet[T]) empty() TreeSet[T] { o.m = o.m.empty(); return o }
 func (o TreeSet[T]) ForEach(f func(x T)) TreeSet[T] {
 	o.m.ForEach(func(k T, v struct{}) { f(k) })
 	return o
 }
-func (o TreeSet[T]) ToSlice() []T { return o.m.Keys() }
+func (o TreeSet[T]) ToSlice() []T { return o.m.KeySlice() }
+
+// 升序和降序惰性遍历，遍历过程中不能修改集合
+func (o TreeSet[T]) All() iter.Seq[T]      { return o.m.Keys() }
+func (o TreeSet[T]) Backward() iter.Seq[T] { return seqKeys(o.m.Backward()) }
+
+// 从指定值开始的惰性范围遍历，参见TreeMap的同名方法
+func (o TreeSet[T]) AscendFrom(x T) iter.Seq[T]  { return seqKeys(o.m.AscendFrom(x)) }
+func (o TreeSet[T]) DescendFrom(x T) iter.Seq[T] { return seqKeys(o.m.DescendFrom(x)) }
+func (o TreeSet[T]) Range(lo, hi T, loInclusive, hiInclusive bool) iter.Seq[T] {
+	return seqKeys(o.m.Range(lo, hi, loInclusive, hiInclusive))
+}
 
 // ==============SetContainer接口=============
 // 基本操作
@@ -571,43 +1027,93 @@ func (o TreeSet[T]) HasSubset(other TreeSet[T]) bool {
 	}
 	return true
 }
+
+// 集合运算返回新的集合，结果总是使用o的比较函数，基于AVL树的拆分与合并，代价为O(m*log(n/m+1))加上复制结果的代价
+// 都按自然顺序排序，或从同一个集合复制或派生的两个集合才会拆分合并，否则退化为逐个插入或删除
 func (o TreeSet[T]) Union(other TreeSet[T]) TreeSet[T] {
-	res := NewTreeSet[T]()
-	o.ForEach(func(k T) { res.Add(k) })
-	other.ForEach(func(k T) { res.Add(k) })
-	return res
+	if o.Len() < other.Len() { // 复制较大的集合，再合并较小的集合
+		return o.copyOf(other).UnionWith(o)
+	}
+	return o.Clone().UnionWith(other)
 }
 func (o TreeSet[T]) Intersect(other TreeSet[T]) TreeSet[T] {
-	res := NewTreeSet[T]()
-	o.ForEach(func(k T) {
-		if other.Has(k) {
-			res.Add(k)
-		}
-	})
+	if o.Len() > other.Len() { // 只需复制较小的集合
+		return o.copyOf(other).IntersectWith(o)
+	}
+	return o.Clone().IntersectWith(other)
+}
+func (o TreeSet[T]) Difference(other TreeSet[T]) TreeSet[T] { return o.Clone().DifferenceWith(other) }
+func (o TreeSet[T]) SymmetricDifference(other TreeSet[T]) TreeSet[T] {
+	if o.Len() < other.Len() {
+		return o.copyOf(other).SymmetricDifferenceWith(o)
+	}
+	return o.Clone().SymmetricDifferenceWith(other)
+}
+
+// 使用o的比较函数复制other的元素，O(m)，比较函数不同时逐个插入
+func (o TreeSet[T]) copyOf(other TreeSet[T]) TreeSet[T] {
+	res := o.empty()
+	if o.m.sameOrder(other.m) {
+		res.m.setRoot(res.m.copyTree(other.m.root()))
+	} else {
+		other.ForEach(func(x T) { res.Add(x) })
+	}
 	return res
 }
-func (o TreeSet[T]) Difference(other TreeSet[T]) TreeSet[T] {
-	res := NewTreeSet[T]()
-	o.ForEach(func(k T) {
-		if !other.Has(k) {
-			res.Add(k)
-		}
+
+// 原地修改o的集合运算，不会修改other
+func (o TreeSet[T]) UnionWith(other TreeSet[T]) TreeSet[T] {
+	o.m.setOp(other.m, o.m.union, func() { other.ForEach(func(x T) { o.Add(x) }) })
+	return o
+}
+func (o TreeSet[T]) IntersectWith(other TreeSet[T]) TreeSet[T] {
+	o.m.setOp(other.m, o.m.intersect, func() { o.DelFunc(func(x T) bool { return !other.Has(x) }) })
+	return o
+}
+func (o TreeSet[T]) DifferenceWith(other TreeSet[T]) TreeSet[T] {
+	o.m.setOp(other.m, o.m.difference, func() { other.ForEach(func(x T) { o.Del(x) }) })
+	return o
+}
+func (o TreeSet[T]) SymmetricDifferenceWith(other TreeSet[T]) TreeSet[T] {
+	o.m.setOp(other.m, o.m.symmetricDifference, func() {
+		other.ForEach(func(x T) {
+			if o.Has(x) {
+				o.Del(x)
+			} else {
+				o.Add(x)
+			}
+		})
 	})
-	return res
+	return o
 }
 
+// 按x拆分集合，o保留小于x的部分，返回大于等于x的部分，O(logn)
+func (o TreeSet[T]) Split(x T) TreeSet[T] { o.m = o.m.Split(x); return o }
+
+// 将other的所有元素移动到o中，要求o的元素都小于other的元素，否则会panic，other会被清空，O(logn)
+func (o TreeSet[T]) Join(other TreeSet[T]) TreeSet[T] { o.m.Join(other.m); return o }
+
 // ==============TreeSetContainer接口=============
 // 子集合截取
-func (o TreeSet[T]) HeadSet(x T) TreeSet[T] {
-	return NewTreeSetFromSlice(o.m.HeadMap(o.m.Rank(x)).Keys())
+// 小于等于x的元素和大于等于x的元素组成的视图，不复制节点，O(1)，需要独立的集合时使用视图的ToTreeSet
+func (o TreeSet[T]) HeadSet(x T) TreeSetView[T] { return TreeSetView[T]{o.m.HeadMapByKey(x, true)} }
+func (o TreeSet[T]) TailSet(x T) TreeSetView[T] { return TreeSetView[T]{o.m.TailMapByKey(x, true)} }
+
+// lo到hi之间的元素组成的视图，边界的开闭与Java的NavigableSet相同
+func (o TreeSet[T]) SubSet(lo, hi T, loInclusive, hiInclusive bool) TreeSetView[T] {
+	return TreeSetView[T]{o.m.SubMap(lo, hi, loInclusive, hiInclusive)}
 }
-func (o TreeSet[T]) TailSet(x T) TreeSet[T] {
-	return NewTreeSetFromSlice(o.m.TailMap(o.m.Rank(x)).Keys())
+func (o TreeSet[T]) CountRange(lo, hi T, loInclusive, hiInclusive bool) int {
+	return o.m.CountRange(lo, hi, loInclusive, hiInclusive)
+}
+func (o TreeSet[T]) DelRange(lo, hi T, loInclusive, hiInclusive bool) TreeSet[T] {
+	o.m.DelRange(lo, hi, loInclusive, hiInclusive)
+	return o
 }
 
 // 排名相关的查找
-func (o TreeSet[T]) Rank(x T) int { return o.m.Rank(x) }
-func (o TreeSet[T]) Kth(k int) T  { return o.m.Select(k).K }
+func (o TreeSet[T]) Rank(x T) int   { return o.m.Rank(x) }
+func (o TreeSet[T]) Select(i int) T { return o.m.Select(i).K }
 
 // 二分查找值，可能会panic
 func (o TreeSet[T]) First() T      { return o.m.First().K }
@@ -617,18 +1123,30 @@ func (o TreeSet[T]) Higher(x T) T  { return o.m.Higher(x).K }
 func (o TreeSet[T]) Floor(x T) T   { return o.m.Floor(x).K }
 func (o TreeSet[T]) Ceiling(x T) T { return o.m.Ceiling(x).K }
 
+// 不会panic的二分查找和排名查找，不存在时返回false
+func (o TreeSet[T]) TryFirst() (T, bool)       { return o.m.First().key() }
+func (o TreeSet[T]) TryLast() (T, bool)        { return o.m.Last().key() }
+func (o TreeSet[T]) TryLower(x T) (T, bool)    { return o.m.Lower(x).key() }
+func (o TreeSet[T]) TryHigher(x T) (T, bool)   { return o.m.Higher(x).key() }
+func (o TreeSet[T]) TryFloor(x T) (T, bool)    { return o.m.Floor(x).key() }
+func (o TreeSet[T]) TryCeiling(x T) (T, bool)  { return o.m.Ceiling(x).key() }
+func (o TreeSet[T]) TrySelect(i int) (T, bool) { return o.m.Select(i).key() }
+
 // ===================================多重有序集===================================
 
-type MultiTreeSet[T cmp.Ordered] struct {
+type MultiTreeSet[T comparable] struct {
 	m      TreeMap[T, int]
 	sumMap map[T]int
 }
 
-func NewMultiTreeSet[T cmp.Ordered]() MultiTreeSet[T] {
+func NewMultiTreeSet[T cmp.Ordered]() MultiTreeSet[T] { return NewMultiTreeSetFunc[T](cmp.Compare[T]) }
+
+// 创建使用自定义比较函数排序的多重集
+func NewMultiTreeSetFunc[T comparable](cmp func(T, T) int) MultiTreeSet[T] {
 	sumMap := make(map[T]int)
 	return MultiTreeSet[T]{
 		sumMap: sumMap,
-		m: NewTreeMap[T, int]().WithFactory(func() int { return 0 }).WithWatcher(func(cur, left, right *MapEntry[T, int]) {
+		m: NewTreeMapFunc[T, int](cmp).WithFactory(func() int { return 0 }).WithWatcher(func(cur, left, right *MapEntry[T, int]) {
 			lCnt, rCnt := 0, 0
 			if left != nil {
 				lCnt = sumMap[left.K]
@@ -657,7 +1175,11 @@ func NewMultiTreeSetFromMap[T cmp.Ordered](m map[T]int) MultiTreeSet[T] {
 }
 
 // --------------------其他转换--------------------
-func (o MultiTreeSet[T]) ToTreeSet() TreeSet[T] { return NewTreeSetFromSlice(o.ToSlice()) } // 去重
+func (o MultiTreeSet[T]) ToTreeSet() TreeSet[T] { // 去重
+	res := NewTreeSetFunc(o.m.cmp)
+	o.ForEach(func(x T) { res.Add(x) })
+	return res
+}
 
 // --------------------ValueContainer接口--------------------
 func (o MultiTreeSet[T]) Len() int { return o.m.Len() }
@@ -667,11 +1189,14 @@ func (o MultiTreeSet[T]) Clear() MultiTreeSet[T] {
 	return o
 }
 func (o MultiTreeSet[T]) Clone() MultiTreeSet[T] {
-	o.m = o.m.Clone()
-	o.sumMap = maps.Clone(o.sumMap)
-	return o
+	res := o.empty()
+	o.ForEachCnt(func(x T, cnt int) { res.AddN(x, cnt) })
+	return res
+}
+func (o MultiTreeSet[T]) empty() MultiTreeSet[T] { return NewMultiTreeSetFunc(o.m.cmp) }
+func (o MultiTreeSet[T]) ForEach(f func(x T)) MultiTreeSet[T] {
+	return o.ForEachCnt(func(k T, v int) { f(k) })
 }
-func (o MultiTreeSet[T]) ForEach(f func(x T)) { o.ForEachCnt(func(k T, v int) { f(k) }) }
 func (o MultiTreeSet[T]) ToSlice() []T {
 	res := make([]T, 0, o.Total())
 	o.ForEachCnt(func(k T, cnt int) {
@@ -710,7 +1235,7 @@ func (o MultiTreeSet[T]) HasSubset(other MultiTreeSet[T]) bool {
 }
 
 func (o MultiTreeSet[T]) Intersect(other MultiTreeSet[T]) MultiTreeSet[T] {
-	res := NewMultiTreeSet[T]()
+	res := o.empty()
 	other.ForEachCnt(func(v T, cnt int) { res.AddN(v, min(cnt, o.Count(v))) })
 	return res
 }
@@ -735,6 +1260,20 @@ func (o MultiTreeSet[T]) ToMap() map[T]int {
 	return res
 }
 
+// 按升序和降序遍历元素及其重数，遍历过程中不能修改集合
+func (o MultiTreeSet[T]) All() iter.Seq2[T, int]      { return o.m.All() }
+func (o MultiTreeSet[T]) Backward() iter.Seq2[T, int] { return o.m.Backward() }
+
+// 从指定值开始的惰性范围遍历元素及其重数，参见TreeMap的同名方法
+func (o MultiTreeSet[T]) AscendFrom(x T) iter.Seq2[T, int]  { return o.m.AscendFrom(x) }
+func (o MultiTreeSet[T]) DescendFrom(x T) iter.Seq2[T, int] { return o.m.DescendFrom(x) }
+func (o MultiTreeSet[T]) Range(lo, hi T, loInclusive, hiInclusive bool) iter.Seq2[T, int] {
+	return o.m.Range(lo, hi, loInclusive, hiInclusive)
+}
+
+// 按升序遍历所有元素，每个元素重复其重数次
+func (o MultiTreeSet[T]) Values() iter.Seq[T] { return repeatKeys(o.All()) }
+
 // 计数操作
 func (o MultiTreeSet[T]) AddN(x T, n int) MultiTreeSet[T] {
 	if n <= 0 {
@@ -795,12 +1334,24 @@ func (o MultiTreeSet[T]) Higher(x T) T  { return o.m.Higher(x).K }
 func (o MultiTreeSet[T]) Floor(x T) T   { return o.m.Floor(x).K }
 func (o MultiTreeSet[T]) Ceiling(x T) T { return o.m.Ceiling(x).K }
 
+// 不会panic的二分查找，不存在时返回false
+func (o MultiTreeSet[T]) TryFirst() (T, bool)      { return o.m.First().key() }
+func (o MultiTreeSet[T]) TryLast() (T, bool)       { return o.m.Last().key() }
+func (o MultiTreeSet[T]) TryLower(x T) (T, bool)   { return o.m.Lower(x).key() }
+func (o MultiTreeSet[T]) TryHigher(x T) (T, bool)  { return o.m.Higher(x).key() }
+func (o MultiTreeSet[T]) TryFloor(x T) (T, bool)   { return o.m.Floor(x).key() }
+func (o MultiTreeSet[T]) TryCeiling(x T) (T, bool) { return o.m.Ceiling(x).key() }
+
 // 截取子集合（不考虑重数）
 func (o MultiTreeSet[T]) HeadSet(k int) MultiTreeSet[T] {
-	return NewMultiTreeSetFromMap(o.m.HeadMap(k).ToMap())
+	res := o.empty()
+	o.m.HeadMap(k).ForEach(func(x T, cnt int) { res.AddN(x, cnt) })
+	return res
 }
 func (o MultiTreeSet[T]) TailSet(k int) MultiTreeSet[T] {
-	return NewMultiTreeSetFromMap(o.m.TailMap(k).ToMap())
+	res := o.empty()
+	o.m.TailMap(k).ForEach(func(x T, cnt int) { res.AddN(x, cnt) })
+	return res
 }
 
 // 排名相关的查找（考虑重数）
@@ -810,9 +1361,9 @@ func (o MultiTreeSet[T]) Rank(x T) int { // 取最小排名
 		if node == nil {
 			return 0
 		}
-		if x == node.K {
+		if c := o.m.cmp(x, node.K); c == 0 {
 			return o.sum(node.Left()) + 1
-		} else if x < node.K {
+		} else if c < 0 {
 			return rank(node.Left())
 		} else {
 			return o.sum(node.Left()) + o.Count(node.K) + rank(node.Right())
@@ -820,7 +1371,9 @@ func (o MultiTreeSet[T]) Rank(x T) int { // 取最小排名
 	}
 	return rank(o.m.GetRoot())
 }
-func (o MultiTreeSet[T]) Select(i int) T { // 如果第i个元素不存在会panic
+func (o MultiTreeSet[T]) Select(i int) T            { return o.selectEntry(i).K } // 如果第i个元素不存在会panic
+func (o MultiTreeSet[T]) TrySelect(i int) (T, bool) { return o.selectEntry(i).key() }
+func (o MultiTreeSet[T]) selectEntry(i int) *MapEntry[T, int] {
 	var _select func(node *MapEntry[T, int], i int) *MapEntry[T, int]
 	_select = func(node *MapEntry[T, int], i int) *MapEntry[T, int] {
 		if node == nil {
@@ -836,7 +1389,7 @@ func (o MultiTreeSet[T]) Select(i int) T { // 如果第i个元素不存在会pan
 			return _select(node.Right(), i-lCnt-curCnt)
 		}
 	}
-	return _select(o.m.GetRoot(), i).K
+	return _select(o.m.GetRoot(), i)
 }
 
 // 辅助函数，计算节点对应子树的计数
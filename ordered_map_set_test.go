@@ -1,7 +1,10 @@
 package gods
 
 import (
+	"cmp"
 	"fmt"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -57,6 +60,21 @@ func Test有序映射(t *testing.T) {
 	fmt.Println("字符串表示:", m)
 }
 
+func Test有序映射Watcher(t *testing.T) {
+	calls := 0
+	m := NewTreeMap[int, int]().WithWatcher(func(cur, left, right *MapEntry[int, int]) { calls++ })
+	m.Set(1, 1).Set(2, 2)
+	if calls == 0 {
+		t.Errorf("Watcher未被调用")
+	}
+
+	// 传入nil时移除Watcher
+	m = NewTreeMap[int, int]().WithWatcher(nil).Set(1, 1).Set(2, 2).Del(1)
+	if m.w != nil || !slices.Equal(m.KeySlice(), []int{2}) {
+		t.Errorf("WithWatcher(nil)错误：%v", m)
+	}
+}
+
 func Test有序集(t *testing.T) {
 	set := NewTreeSet[int]()
 	for i := 9; i >= 0; i-- {
@@ -92,3 +110,379 @@ func Test多重有序集(t *testing.T) {
 	}
 	fmt.Println("删除0-9之后：", set)
 }
+
+func Test有序映射迭代器(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	for i := range 100 {
+		m.Set(i, i*i)
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, RangeN(100).GetSlice()) {
+		t.Errorf("TreeMap.Keys = %v", got)
+	}
+	for k, v := range m.Backward() {
+		if k < 95 {
+			break
+		}
+		fmt.Println(k, v)
+	}
+
+	s := NewMultiTreeSetFromSlice([]int{3, 1, 1, 2})
+	if got := slices.Collect(s.Values()); !slices.Equal(got, []int{1, 1, 2, 3}) {
+		t.Errorf("MultiTreeSet.Values = %v", got)
+	}
+	fmt.Println("TreeSet逆序：", slices.Collect(NewTreeSetFromSlice([]int{3, 1, 2}).Backward()))
+}
+
+func Test有序映射范围遍历(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	for i := 0; i < 100; i += 2 {
+		m.Set(i, i)
+	}
+	if got := slices.Collect(seqKeys(m.AscendFrom(41))); got[0] != 42 || len(got) != 29 {
+		t.Errorf("AscendFrom(41) = %v", got)
+	}
+	if got := slices.Collect(seqKeys(m.DescendFrom(41))); got[0] != 40 || len(got) != 21 {
+		t.Errorf("DescendFrom(41) = %v", got)
+	}
+	cases := []struct {
+		lo, hi       int
+		loInc, hiInc bool
+		want         []int
+	}{
+		{10, 16, true, true, []int{10, 12, 14, 16}},
+		{10, 16, false, false, []int{12, 14}},
+		{11, 15, true, false, []int{12, 14}},
+		{-5, 2, true, true, []int{0, 2}},
+		{98, 200, false, true, nil},
+	}
+	for _, c := range cases {
+		if got := slices.Collect(seqKeys(m.Range(c.lo, c.hi, c.loInc, c.hiInc))); !slices.Equal(got, c.want) {
+			t.Errorf("Range(%v, %v, %v, %v) = %v, want %v", c.lo, c.hi, c.loInc, c.hiInc, got, c.want)
+		}
+	}
+
+	s := NewTreeSetFromSlice([]int{1, 3, 5, 7, 9})
+	fmt.Println("前两个>=4的值：")
+	i := 0
+	for x := range s.AscendFrom(4) {
+		if i == 2 {
+			break
+		}
+		fmt.Println(x)
+		i++
+	}
+}
+
+func Test自定义比较函数(t *testing.T) {
+	type task struct {
+		prio, id int
+	}
+	m := NewTreeMapFunc[task, string](func(a, b task) int {
+		return cmp.Or(cmp.Compare(a.prio, b.prio), cmp.Compare(a.id, b.id))
+	})
+	m.Set(task{2, 1}, "b").Set(task{1, 2}, "a").Set(task{2, 0}, "c")
+	fmt.Println(m)
+	if e := m.Floor(task{2, 0}); e == nil || e.V != "c" {
+		t.Errorf("Floor = %v", e)
+	}
+	if r := m.Rank(task{2, 0}); r != 2 {
+		t.Errorf("Rank = %v", r)
+	}
+
+	s := NewTreeSetFunc(Str.CmpFold)
+	s.Add("b").Add("A").Add("a").Add("C")
+	if s.Len() != 3 || s.First() != "A" {
+		t.Errorf("不区分大小写的集合：%v", s)
+	}
+	fmt.Println(s, s.Union(NewTreeSetFunc(Str.CmpFold).Add("d")))
+
+	ms := NewMultiTreeSetFunc(func(a, b int) int { return cmp.Compare(b, a) }) // 降序
+	for _, x := range []int{1, 2, 2, 3} {
+		ms.Add(x)
+	}
+	if ms.Select(1) != 3 || ms.Rank(1) != 4 {
+		t.Errorf("降序多重集：%v", ms)
+	}
+	fmt.Println(ms, ms.Clone().Add(5).Total())
+}
+
+func Test有序集合Try操作(t *testing.T) {
+	s := NewTreeSet[int]().Add(1).Add(5)
+	ms := NewMultiTreeSet[int]().AddN(1, 2).Add(5)
+	floor, ok := s.TryFloor(4)
+	_, ok2 := s.TryLower(1)
+	_, ok3 := s.TrySelect(3)
+	fmt.Println(floor, ok, ok2, ok3)
+	if floor != 1 || !ok || ok2 || ok3 {
+		t.Errorf("TreeSet的Try操作错误")
+	}
+	sel, ok := ms.TrySelect(3)
+	_, ok2 = ms.TrySelect(4)
+	_, ok3 = ms.TryHigher(5)
+	if sel != 5 || !ok || ok2 || ok3 {
+		t.Errorf("MultiTreeSet的Try操作错误")
+	}
+	if _, ok := NewTreeSet[int]().TryFirst(); ok {
+		t.Errorf("空集合TryFirst应返回false")
+	}
+
+	m := NewTreeMap[int, int]().Set(0, 0)
+	if v, ok := m.Lookup(0); v != 0 || !ok {
+		t.Errorf("TreeMap的Lookup错误")
+	}
+	if _, ok := m.ToPersistent().Lookup(1); ok {
+		t.Errorf("PersistentTreeMap的Lookup错误")
+	}
+}
+
+func Test有序映射拆分合并(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for i := range 20 {
+		m.Set(i, fmt.Sprint(i))
+	}
+	right := m.Split(8)
+	fmt.Println("拆分：", m, right)
+	checkAVL(t, m.root(), m.cmp)
+	checkAVL(t, right.root(), right.cmp)
+	if m.Len() != 8 || right.Len() != 12 || m.Last().K != 7 || right.First().K != 8 {
+		t.Errorf("Split错误")
+	}
+	if empty := m.Clone().Split(100); empty.Len() != 0 {
+		t.Errorf("Split到末尾应返回空映射")
+	}
+
+	m.Join(right)
+	checkAVL(t, m.root(), m.cmp)
+	if m.Len() != 20 || right.Len() != 0 || !slices.Equal(m.KeySlice(), RangeN(20).ToSlice()) {
+		t.Errorf("Join错误：%v", m)
+	}
+
+	// 高度相差很大的树合并
+	small := NewTreeMap[int, string]().Set(100, "x")
+	m.Join(small)
+	checkAVL(t, m.root(), m.cmp)
+	if m.Len() != 21 || m.Last().K != 100 {
+		t.Errorf("Join高度不同的树错误")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("键的范围重叠时Join应panic")
+		}
+	}()
+	m.Join(NewTreeMap[int, string]().Set(50, "y"))
+}
+
+func Test有序集运算(t *testing.T) {
+	a, b := NewTreeSetFromSlice([]int{1, 2, 3, 4, 5}), NewTreeSetFromSlice([]int{4, 5, 6, 7})
+	fmt.Println("并集：", a.Union(b), "交集：", a.Intersect(b), "差集：", a.Difference(b), "对称差：", a.SymmetricDifference(b))
+	if !slices.Equal(a.SymmetricDifference(b).ToSlice(), []int{1, 2, 3, 6, 7}) || a.Len() != 5 || b.Len() != 4 {
+		t.Errorf("SymmetricDifference错误")
+	}
+	if a.Clone().UnionWith(a).Len() != 5 || a.Clone().DifferenceWith(a).Len() != 0 {
+		t.Errorf("与自身运算错误")
+	}
+
+	// 与暴力结果比较
+	for range 200 {
+		x, y := NewTreeSet[int](), NewTreeSet[int]()
+		mx, my := map[int]bool{}, map[int]bool{}
+		for range rand.Intn(100) {
+			v := rand.Intn(150)
+			x.Add(v)
+			mx[v] = true
+		}
+		for range rand.Intn(100) {
+			v := rand.Intn(150)
+			y.Add(v)
+			my[v] = true
+		}
+		var union, inter, diff, sym []int
+		for v := range 150 {
+			if mx[v] || my[v] {
+				union = append(union, v)
+			}
+			if mx[v] && my[v] {
+				inter = append(inter, v)
+			}
+			if mx[v] && !my[v] {
+				diff = append(diff, v)
+			}
+			if mx[v] != my[v] {
+				sym = append(sym, v)
+			}
+		}
+		xs, ys := x.ToSlice(), y.ToSlice()
+		for _, c := range []struct {
+			name string
+			got  TreeSet[int]
+			want []int
+		}{
+			{"Union", x.Union(y), union},
+			{"Intersect", x.Intersect(y), inter},
+			{"Difference", x.Difference(y), diff},
+			{"SymmetricDifference", x.SymmetricDifference(y), sym},
+			{"UnionWith", x.Clone().UnionWith(y), union},
+			{"IntersectWith", y.Clone().IntersectWith(x), inter},
+		} {
+			checkAVL(t, c.got.m.root(), c.got.m.cmp)
+			if !slices.Equal(c.got.ToSlice(), c.want) {
+				t.Fatalf("%s错误：%v %v", c.name, c.got, c.want)
+			}
+		}
+		if !slices.Equal(x.ToSlice(), xs) || !slices.Equal(y.ToSlice(), ys) {
+			t.Fatalf("集合运算不应修改原集合")
+		}
+	}
+
+	// Extend使用other的值
+	m := NewTreeMap[int, string]().Set(1, "a").Set(2, "b")
+	m.Extend(NewTreeMap[int, string]().Set(2, "B").Set(3, "C"))
+	if !slices.Equal(m.ValueSlice(), []string{"a", "B", "C"}) {
+		t.Errorf("Extend错误：%v", m)
+	}
+
+	// 比较函数不同时逐个处理，结果使用o的比较函数
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+	rm := NewTreeMapFunc[int, int](desc).Set(8, 8).Set(6, 6).Set(4, 4)
+	rm.Extend(NewTreeMapFromMap(map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 5: 5, 7: 7, 9: 9}))
+	checkAVL(t, rm.root(), rm.cmp)
+	if !slices.Equal(rm.KeySlice(), []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}) || !rm.Has(7) {
+		t.Errorf("比较函数不同时Extend错误：%v", rm.KeySlice())
+	}
+	small := NewTreeSetFunc(desc).Add(1).Add(2).Add(10)
+	big := NewTreeSetFromSlice([]int{2, 3, 4, 5, 6})
+	for _, c := range []struct {
+		name string
+		got  TreeSet[int]
+		want []int
+	}{
+		{"Union", small.Union(big), []int{10, 6, 5, 4, 3, 2, 1}},
+		{"Intersect", big.Intersect(small), []int{2}},
+		{"Difference", small.Difference(big), []int{10, 1}},
+		{"SymmetricDifference", small.SymmetricDifference(big), []int{10, 6, 5, 4, 3, 1}},
+		{"IntersectWith", big.Clone().IntersectWith(small), []int{2}},
+		{"DifferenceWith", big.Clone().DifferenceWith(small), []int{3, 4, 5, 6}},
+	} {
+		checkAVL(t, c.got.m.root(), c.got.m.cmp)
+		if !slices.Equal(c.got.ToSlice(), c.want) {
+			t.Errorf("比较函数不同时%s错误：%v", c.name, c.got)
+		}
+	}
+
+	// 只比较比较函数的标识，自然顺序和复制得到的集合可以直接拆分合并
+	if !big.m.sameOrder(NewTreeSet[int]().m) || !small.m.sameOrder(small.Clone().Difference(big).m) ||
+		small.m.sameOrder(NewTreeSetFunc(desc).m) {
+		t.Errorf("比较函数的标识错误")
+	}
+}
+
+// 原来逐个插入元素的实现
+func unionByAdd[T comparable](a, b TreeSet[T]) TreeSet[T] {
+	res := a.empty()
+	a.ForEach(func(k T) { res.Add(k) })
+	b.ForEach(func(k T) { res.Add(k) })
+	return res
+}
+
+func benchmarkSets(n, m int) (TreeSet[int], TreeSet[int]) {
+	a, b := NewTreeSet[int](), NewTreeSet[int]()
+	for i := range n {
+		a.Add(i * 2)
+	}
+	for i := range m {
+		b.Add(i*2*n/m + 1)
+	}
+	return a, b
+}
+
+func BenchmarkTreeSetUnion逐个插入(b *testing.B) {
+	x, y := benchmarkSets(100000, 100000)
+	for b.Loop() {
+		unionByAdd(x, y)
+	}
+}
+func BenchmarkTreeSetUnion(b *testing.B) {
+	x, y := benchmarkSets(100000, 100000)
+	for b.Loop() {
+		x.Union(y)
+	}
+}
+func BenchmarkTreeSetUnionWith小集合逐个插入(b *testing.B) {
+	x, y := benchmarkSets(100000, 100)
+	for b.Loop() {
+		y.ForEach(func(k int) { x.Add(k) })
+		y.ForEach(func(k int) { x.Del(k) })
+	}
+}
+func BenchmarkTreeSetUnionWith小集合(b *testing.B) {
+	x, y := benchmarkSets(100000, 100)
+	for b.Loop() {
+		x.UnionWith(y).DifferenceWith(y)
+	}
+}
+func BenchmarkTreeSet小集合与大集合求交和差(b *testing.B) { // 代价只与小集合的大小和logn有关
+	x, y := benchmarkSets(1000000, 3)
+	for b.Loop() {
+		y.Intersect(x)
+		y.Clone().DifferenceWith(x)
+	}
+}
+
+func Test有序映射按键范围操作(t *testing.T) {
+	m := NewTreeMap[int, int]()
+	for i := 0; i < 20; i += 2 {
+		m.Set(i, i*i)
+	}
+	fmt.Println("[4,10)：", m.SubMap(4, 10, true, false), "<=6：", m.HeadMapByKey(6, true), ">6：", m.TailMapByKey(6, false))
+	if m.CountRange(4, 10, true, false) != 3 || m.CountRange(4, 10, false, true) != 3 || m.CountRange(10, 4, true, true) != 0 {
+		t.Errorf("CountRange错误")
+	}
+	if !slices.Equal(m.HeadMapByKey(6, false).KeySlice(), []int{0, 2, 4}) || m.TailMapByKey(6, true).Len() != 7 {
+		t.Errorf("HeadMapByKey或TailMapByKey错误")
+	}
+
+	// 与暴力结果比较
+	bounds := []bool{true, false}
+	for range 300 {
+		m := NewTreeMap[int, int]()
+		keys := map[int]bool{}
+		for range rand.Intn(60) {
+			k := rand.Intn(50)
+			m.Set(k, k)
+			keys[k] = true
+		}
+		lo, hi := rand.Intn(55)-2, rand.Intn(55)-2
+		loInc, hiInc := bounds[rand.Intn(2)], bounds[rand.Intn(2)]
+		in := func(k int) bool { return (k > lo || loInc && k == lo) && (k < hi || hiInc && k == hi) }
+		var want, rest []int
+		for k := range 50 {
+			if keys[k] && in(k) {
+				want = append(want, k)
+			} else if keys[k] {
+				rest = append(rest, k)
+			}
+		}
+		sub := m.SubMap(lo, hi, loInc, hiInc).ToTreeMap()
+		checkAVL(t, sub.root(), sub.cmp)
+		if m.CountRange(lo, hi, loInc, hiInc) != len(want) || !slices.Equal(sub.KeySlice(), want) {
+			t.Fatalf("CountRange或SubMap错误：%v %d %d %v %v", m, lo, hi, loInc, hiInc)
+		}
+		m.DelRange(lo, hi, loInc, hiInc)
+		checkAVL(t, m.root(), m.cmp)
+		if !slices.Equal(m.KeySlice(), rest) {
+			t.Fatalf("DelRange错误：%v %d %d %v %v", m, lo, hi, loInc, hiInc)
+		}
+	}
+
+	s := NewTreeSetFromSlice([]int{1, 3, 5, 7, 9})
+	if !slices.Equal(s.HeadSet(5).ToSlice(), []int{1, 3, 5}) || !slices.Equal(s.TailSet(4).ToSlice(), []int{5, 7, 9}) {
+		t.Errorf("HeadSet或TailSet错误")
+	}
+	if s.CountRange(2, 8, true, true) != 3 || !slices.Equal(s.SubSet(3, 9, false, false).ToSlice(), []int{5, 7}) {
+		t.Errorf("SubSet或CountRange错误")
+	}
+	if !slices.Equal(s.DelRange(3, 7, true, false).ToSlice(), []int{1, 7, 9}) {
+		t.Errorf("DelRange错误")
+	}
+}
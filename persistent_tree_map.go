@@ -0,0 +1,153 @@
+package gods
+
+import (
+	"cmp"
+	"iter"
+	"strings"
+)
+
+// ===================================可持久化有序映射===================================
+
+// 不可变的AVL树映射，Set和Del通过路径复制返回新版本，代价为O(logn)，新旧版本共享未修改的节点
+// 所有版本都不会再被修改，可以在多个协程中无锁读取
+type PersistentTreeMap[K comparable, V any] struct {
+	root *avlNode[K, V]
+	cmp  func(K, K) int
+}
+
+func NewPersistentTreeMap[K cmp.Ordered, V any]() PersistentTreeMap[K, V] {
+	return NewPersistentTreeMapFunc[K, V](cmp.Compare[K])
+}
+func NewPersistentTreeMapFunc[K comparable, V any](cmp func(K, K) int) PersistentTreeMap[K, V] {
+	return PersistentTreeMap[K, V]{cmp: cmp}
+}
+
+// 复制TreeMap的树结构，创建可持久化映射，代价为O(n)
+func (o TreeMap[K, V]) ToPersistent() PersistentTreeMap[K, V] {
+	return PersistentTreeMap[K, V]{root: o.root().deepCopy(), cmp: o.cmp}
+}
+
+// 复制树结构，创建可修改的TreeMap，代价为O(n)
+func (o PersistentTreeMap[K, V]) ToTreeMap() TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](o.cmp).setRoot(o.root.deepCopy())
+}
+
+// 创建共享树结构的只读TreeMap视图，用于复用TreeMap的查询方法，不能调用修改方法
+func (o PersistentTreeMap[K, V]) view() TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](o.cmp).setRoot(o.root)
+}
+
+// --------------------Container接口--------------------
+
+func (o PersistentTreeMap[K, V]) Len() int { return o.view().Len() }
+func (o PersistentTreeMap[K, V]) String() string {
+	return strings.Replace(o.view().String(), "TreeMap", "PersistentTreeMap", 1)
+}
+
+// --------------------修改操作（返回新版本）--------------------
+
+func (o PersistentTreeMap[K, V]) Set(k K, v V) PersistentTreeMap[K, V] {
+	var _set func(node *avlNode[K, V]) *avlNode[K, V]
+	_set = func(node *avlNode[K, V]) *avlNode[K, V] {
+		if node == nil {
+			return newAVLNode(k, v)
+		}
+		node = node.copy()
+		if c := o.cmp(k, node.k); c < 0 {
+			node.left = _set(node.left)
+		} else if c > 0 {
+			node.right = _set(node.right)
+		} else {
+			node.v = v
+			return node
+		}
+		return node.updateStatus(nil).balanceCopy()
+	}
+
+	o.root = _set(o.root)
+	return o
+}
+func (o PersistentTreeMap[K, V]) Del(k K) PersistentTreeMap[K, V] {
+	if !o.Has(k) { // 不存在时直接返回当前版本，避免复制路径
+		return o
+	}
+	var _del func(node *avlNode[K, V], k K) *avlNode[K, V]
+	_del = func(node *avlNode[K, V], k K) *avlNode[K, V] {
+		if node == nil {
+			return nil
+		}
+		c := o.cmp(k, node.k)
+		if c == 0 && node.right == nil {
+			return node.left
+		} else if c == 0 && node.left == nil {
+			return node.right
+		}
+		node = node.copy()
+		if c < 0 {
+			node.left = _del(node.left, k)
+		} else if c > 0 {
+			node.right = _del(node.right, k)
+		} else {
+			next := node.right
+			for next.left != nil { // 找到后继节点
+				next = next.left
+			}
+			node.k, node.v = next.k, next.v
+			node.right = _del(node.right, next.k)
+		}
+		return node.updateStatus(nil).balanceCopy()
+	}
+
+	o.root = _del(o.root, k)
+	return o
+}
+
+// 在新复制的节点上做平衡，旋转会修改子节点，因此先复制涉及的子节点
+func (o *avlNode[K, V]) balanceCopy() *avlNode[K, V] {
+	if o.factor() == -2 {
+		o.left = o.left.copy()
+		if o.left.factor() > 0 { // LR
+			o.left.right = o.left.right.copy()
+		}
+	} else if o.factor() == 2 {
+		o.right = o.right.copy()
+		if o.right.factor() < 0 { // RL
+			o.right.left = o.right.left.copy()
+		}
+	}
+	return o.balance(nil)
+}
+
+// --------------------查询操作--------------------
+
+func (o PersistentTreeMap[K, V]) Get(k K) V              { return o.view().Get(k) }
+func (o PersistentTreeMap[K, V]) GetOr(k K, v V) V       { return o.view().GetOr(k, v) }
+func (o PersistentTreeMap[K, V]) Lookup(k K) (V, bool)   { return o.view().Lookup(k) }
+func (o PersistentTreeMap[K, V]) Has(k K) bool           { return o.view().Has(k) }
+func (o PersistentTreeMap[K, V]) ForEach(f func(K, V))   { o.view().ForEach(f) }
+func (o PersistentTreeMap[K, V]) ToMap() map[K]V         { return o.view().ToMap() }
+func (o PersistentTreeMap[K, V]) KeySlice() []K          { return o.view().KeySlice() }
+func (o PersistentTreeMap[K, V]) ValueSlice() []V        { return o.view().ValueSlice() }
+func (o PersistentTreeMap[K, V]) First() *MapEntry[K, V] { return o.view().First() }
+func (o PersistentTreeMap[K, V]) Last() *MapEntry[K, V]  { return o.view().Last() }
+
+// 二分查找键值对，不存在时返回nil
+func (o PersistentTreeMap[K, V]) Lower(k K) *MapEntry[K, V]   { return o.view().Lower(k) }
+func (o PersistentTreeMap[K, V]) Higher(k K) *MapEntry[K, V]  { return o.view().Higher(k) }
+func (o PersistentTreeMap[K, V]) Floor(k K) *MapEntry[K, V]   { return o.view().Floor(k) }
+func (o PersistentTreeMap[K, V]) Ceiling(k K) *MapEntry[K, V] { return o.view().Ceiling(k) }
+
+// 排名相关操作
+func (o PersistentTreeMap[K, V]) Rank(k K) int                 { return o.view().Rank(k) }
+func (o PersistentTreeMap[K, V]) Select(i int) *MapEntry[K, V] { return o.view().Select(i) }
+
+// 迭代器
+func (o PersistentTreeMap[K, V]) All() iter.Seq2[K, V]            { return o.view().All() }
+func (o PersistentTreeMap[K, V]) Keys() iter.Seq[K]               { return o.view().Keys() }
+func (o PersistentTreeMap[K, V]) Values() iter.Seq[V]             { return o.view().Values() }
+func (o PersistentTreeMap[K, V]) Backward() iter.Seq2[K, V]       { return o.view().Backward() }
+func (o PersistentTreeMap[K, V]) AscendFrom(k K) iter.Seq2[K, V]  { return o.view().AscendFrom(k) }
+func (o PersistentTreeMap[K, V]) DescendFrom(k K) iter.Seq2[K, V] { return o.view().DescendFrom(k) }
+func (o PersistentTreeMap[K, V]) Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V] {
+	return o.view().Range(lo, hi, loInclusive, hiInclusive)
+}
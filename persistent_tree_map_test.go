@@ -0,0 +1,122 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// 检查AVL树的平衡性和节点信息
+func checkAVL[K comparable, V any](t *testing.T, node *avlNode[K, V], cmp func(K, K) int) (depth, size int) {
+	if node == nil {
+		return 0, 0
+	}
+	ld, ls := checkAVL(t, node.left, cmp)
+	rd, rs := checkAVL(t, node.right, cmp)
+	if node.left != nil && cmp(node.left.k, node.k) >= 0 || node.right != nil && cmp(node.right.k, node.k) <= 0 {
+		t.Errorf("节点%v的顺序错误", node.k)
+	}
+	if rd-ld > 1 || ld-rd > 1 {
+		t.Errorf("节点%v不平衡", node.k)
+	}
+	if node.depth != max(ld, rd)+1 || node.size != ls+rs+1 {
+		t.Errorf("节点%v的高度或大小错误", node.k)
+	}
+	return node.depth, node.size
+}
+
+func Test可持久化有序映射(t *testing.T) {
+	v0 := NewPersistentTreeMap[int, string]()
+	v1 := v0.Set(1, "a").Set(3, "c").Set(5, "e")
+	v2 := v1.Set(2, "b").Set(3, "C")
+	v3 := v2.Del(1)
+	fmt.Println("v0：", v0)
+	fmt.Println("v1：", v1)
+	fmt.Println("v2：", v2)
+	fmt.Println("v3：", v3)
+	if v0.Len() != 0 || v1.Get(3) != "c" || v2.Get(3) != "C" || !v2.Has(1) || v3.Has(1) {
+		t.Errorf("旧版本被修改")
+	}
+	if v3.Del(100).root != v3.root {
+		t.Errorf("删除不存在的键不应复制路径")
+	}
+
+	fmt.Println("小于等于4：", v2.Floor(4).K)
+	fmt.Println("大于等于4：", v2.Ceiling(4).K)
+	fmt.Println("小于2：", v2.Lower(2).K)
+	fmt.Println("大于5：", v2.Higher(5))
+	fmt.Println("3的排名：", v2.Rank(3))
+	fmt.Println("第2个节点：", v2.Select(2).K)
+	fmt.Println("[2,5)：", slices.Collect(seqKeys(v2.Range(2, 5, true, false))))
+	if v2.Floor(4).K != 3 || v2.Ceiling(4).K != 5 || v2.Rank(3) != 3 || v2.Select(2).K != 2 {
+		t.Errorf("二分查找或排名错误")
+	}
+
+	// 和TreeMap互相转换
+	m := v2.ToTreeMap()
+	m.Set(4, "d")
+	p := m.ToPersistent()
+	m.Del(2)
+	fmt.Println("TreeMap：", m)
+	fmt.Println("转换回的PersistentTreeMap：", p)
+	if v2.Has(4) || !p.Has(2) || !p.Has(4) {
+		t.Errorf("转换后不应共享节点")
+	}
+}
+
+func Test可持久化有序映射随机操作(t *testing.T) {
+	versions := []PersistentTreeMap[int, int]{NewPersistentTreeMap[int, int]()}
+	snapshots := []map[int]int{{}}
+	for i := range 2000 {
+		cur, snap := versions[len(versions)-1], make(map[int]int)
+		for k, v := range snapshots[len(snapshots)-1] {
+			snap[k] = v
+		}
+		k := rand.Intn(200)
+		if rand.Intn(3) == 0 {
+			cur = cur.Del(k)
+			delete(snap, k)
+		} else {
+			cur = cur.Set(k, i)
+			snap[k] = i
+		}
+		versions, snapshots = append(versions, cur), append(snapshots, snap)
+	}
+	for i, ver := range versions {
+		checkAVL(t, ver.root, ver.cmp)
+		if ver.Len() != len(snapshots[i]) {
+			t.Fatalf("版本%d的长度错误", i)
+		}
+		for k, v := range ver.All() {
+			if snapshots[i][k] != v {
+				t.Fatalf("版本%d的键%d错误", i, k)
+			}
+		}
+	}
+}
+
+func Test可持久化有序映射并发读取(t *testing.T) {
+	m := NewPersistentTreeMap[int, int]()
+	for i := range 1000 {
+		m = m.Set(i, i)
+	}
+	wg := sync.WaitGroup{}
+	for range 4 {
+		snapshot, want := m, m.ValueSlice()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, k := range snapshot.KeySlice() {
+				if snapshot.Get(k) != want[i] || snapshot.Rank(k) != i+1 {
+					t.Errorf("快照读取错误")
+				}
+			}
+		}()
+		for i := range 100 { // 写入只产生新版本，不影响其他协程持有的快照
+			m = m.Set(i, -i).Del(i + 500)
+		}
+	}
+	wg.Wait()
+}
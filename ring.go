@@ -0,0 +1,170 @@
+package gods
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// ===================================环形缓冲区===================================
+
+// 固定容量的环形缓冲区，默认在满时覆盖最早的元素，也可以设置为满时拒绝插入
+type Ring[T any] struct {
+	data   []T  // 容量固定，不会重新分配
+	head   *int // 第一个元素的位置
+	size   *int
+	reject bool // 满时拒绝插入
+}
+
+func NewRing[T any](capacity int) Ring[T] {
+	if capacity <= 0 {
+		panic("容量必须大于0：" + strconv.Itoa(capacity))
+	}
+	return Ring[T]{data: make([]T, capacity), head: new(int), size: new(int)}
+}
+
+// 设置为满时拒绝插入，PushBack会panic，TryPushBack返回false
+func (o Ring[T]) WithRejectMode() Ring[T] { o.reject = true; return o }
+
+// --------------------辅助函数--------------------
+
+// 第i个元素在data中的位置
+func (o Ring[T]) pos(i int) int { return (*o.head + i) % len(o.data) }
+
+// 支持负值索引
+func (o Ring[T]) idx(i int) int {
+	preIdx := i
+	if i < 0 {
+		i += *o.size
+	}
+	if i < 0 || i >= *o.size {
+		panic("索引超出范围：" + strconv.Itoa(preIdx))
+	}
+	return o.pos(i)
+}
+
+// --------------------ValueContainer接口--------------------
+func (o Ring[T]) Len() int       { return *o.size }
+func (o Ring[T]) Cap() int       { return len(o.data) }
+func (o Ring[T]) IsFull() bool   { return o.Len() == o.Cap() }
+func (o Ring[T]) String() string { return "Ring" + fmt.Sprint(o.ToSlice()) }
+func (o Ring[T]) Clear() Ring[T] {
+	clear(o.data)
+	*o.head, *o.size = 0, 0
+	return o
+}
+func (o Ring[T]) Clone() Ring[T] {
+	head, size := *o.head, *o.size
+	o.data = append([]T{}, o.data...)
+	o.head, o.size = &head, &size
+	return o
+}
+func (o Ring[T]) ForEach(f func(T)) Ring[T] {
+	for _, x := range o.All() {
+		f(x)
+	}
+	return o
+}
+func (o Ring[T]) ToSlice() []T {
+	res := make([]T, 0, o.Len())
+	for _, x := range o.All() {
+		res = append(res, x)
+	}
+	return res
+}
+
+// 从前往后和从后往前遍历索引和元素
+func (o Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range o.Len() {
+			if !yield(i, o.data[o.pos(i)]) {
+				return
+			}
+		}
+	}
+}
+func (o Ring[T]) Values() iter.Seq[T] { return seqValues(o.All()) }
+func (o Ring[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := o.Len() - 1; i >= 0; i-- {
+			if !yield(i, o.data[o.pos(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// --------------------基本操作--------------------
+
+// 随机访问，支持负值索引
+func (o Ring[T]) Get(i int) T            { return o.data[o.idx(i)] }
+func (o Ring[T]) Set(i int, x T) Ring[T] { o.data[o.idx(i)] = x; return o }
+func (o Ring[T]) Front() T               { return o.Get(0) }
+func (o Ring[T]) Back() T                { return o.Get(-1) }
+
+// 在尾部插入，满时覆盖最早的元素，拒绝模式下会panic
+func (o Ring[T]) PushBack(x T) Ring[T] {
+	if !o.TryPushBack(x) {
+		panic("环形缓冲区已满")
+	}
+	return o
+}
+func (o Ring[T]) PopFront() T {
+	if o.Len() == 0 {
+		panic("环形缓冲区为空")
+	}
+	x := o.data[*o.head]
+	o.data[*o.head] = *new(T)
+	*o.head = o.pos(1)
+	*o.size--
+	return x
+}
+func (o Ring[T]) PopBack() T {
+	if o.Len() == 0 {
+		panic("环形缓冲区为空")
+	}
+	i := o.pos(*o.size - 1)
+	x := o.data[i]
+	o.data[i] = *new(T)
+	*o.size--
+	return x
+}
+
+// 队列操作
+func (o Ring[T]) Push(x T) Ring[T] { return o.PushBack(x) }
+func (o Ring[T]) Pop() T           { return o.PopFront() }
+func (o Ring[T]) Peek() T          { return o.Front() }
+
+// 不会panic的操作，拒绝模式下满时TryPushBack返回false，为空时TryPop、TryPeek、TryPopFront和TryPopBack返回false
+func (o Ring[T]) TryPushBack(x T) bool {
+	if o.IsFull() {
+		if o.reject {
+			return false
+		}
+		o.data[*o.head] = x // 覆盖最早的元素
+		*o.head = o.pos(1)
+		return true
+	}
+	o.data[o.pos(*o.size)] = x
+	*o.size++
+	return true
+}
+func (o Ring[T]) TryPopFront() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PopFront(), true
+}
+func (o Ring[T]) TryPopBack() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PopBack(), true
+}
+func (o Ring[T]) TryPop() (T, bool) { return o.TryPopFront() }
+func (o Ring[T]) TryPeek() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Front(), true
+}
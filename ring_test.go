@@ -0,0 +1,54 @@
+package gods
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test环形缓冲区(t *testing.T) {
+	r := NewRing[int](3)
+	for i := range 5 {
+		r.PushBack(i)
+		fmt.Println("插入", i, "：", r)
+	}
+	if !slices.Equal(r.ToSlice(), []int{2, 3, 4}) || r.Front() != 2 || r.Back() != 4 || r.Get(-2) != 3 {
+		t.Errorf("覆盖模式错误")
+	}
+	fmt.Println("从后往前：")
+	for i, x := range r.Backward() {
+		fmt.Print(i, ":", x, " ")
+	}
+	fmt.Println()
+
+	clone := r.Clone()
+	fmt.Println("弹出：", r.PopFront(), r.PopBack(), r)
+	if r.Len() != 1 || r.Front() != 3 || clone.Len() != 3 {
+		t.Errorf("弹出错误")
+	}
+	r.PushBack(5).PushBack(6)
+	if !slices.Equal(r.ToSlice(), []int{3, 5, 6}) {
+		t.Errorf("绕回插入错误：%v", r)
+	}
+
+	rej := NewRing[string](2).WithRejectMode()
+	ok1, ok2, ok3 := rej.TryPushBack("a"), rej.TryPushBack("b"), rej.TryPushBack("c")
+	fmt.Println("拒绝模式：", rej, ok1, ok2, ok3)
+	if !ok1 || !ok2 || ok3 || rej.Back() != "b" {
+		t.Errorf("拒绝模式错误")
+	}
+	rej.Clear()
+	if _, ok := rej.TryPopFront(); ok {
+		t.Errorf("空缓冲区TryPopFront应返回false")
+	}
+	if _, ok := rej.TryPeek(); ok {
+		t.Errorf("空缓冲区TryPeek应返回false")
+	}
+	rej.Push("x").Push("y")
+	if x, ok := rej.TryPeek(); x != "x" || !ok || rej.Len() != 2 {
+		t.Errorf("TryPeek错误")
+	}
+	if x, ok := rej.TryPop(); x != "x" || !ok || rej.Len() != 1 {
+		t.Errorf("TryPop错误")
+	}
+}
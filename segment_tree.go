@@ -0,0 +1,293 @@
+package gods
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// ===================================线段树===================================
+
+// 基于幺半群的线段树，T为元素类型，L为区间修改的懒标记类型
+// 索引从0开始，支持负值索引，区间都是左闭右开
+type SegmentTree[T, L any] struct {
+	d *segmentTreeData[T, L]
+}
+
+type segmentTreeData[T, L any] struct {
+	n, size, log int // 元素个数，叶子个数（2的幂），树的高度
+	tree         []T // 下标从1开始，节点k的子节点为2k和2k+1
+	lazy         []L // 内部节点的懒标记，不支持区间修改时为nil
+
+	e  T            // 单位元，满足op(e, x) = op(x, e) = x
+	op func(T, T) T // 满足结合律的合并函数
+	id L            // 恒等标记，满足apply(id, x, n) = x
+	// 将标记作用到长度为n的区间的合并结果上
+	apply func(tag L, x T, n int) T
+	// 合并标记，返回先作用inner再作用outer的效果
+	compose func(outer, inner L) L
+}
+
+// 创建不支持区间修改的线段树，O(n)
+func NewSegmentTree[T any](arr Array[T], e T, op func(T, T) T) SegmentTree[T, struct{}] {
+	return newSegmentTree[T, struct{}](arr, &segmentTreeData[T, struct{}]{e: e, op: op})
+}
+
+// 创建支持区间修改的线段树，O(n)
+// apply(tag, x, n)将标记作用到长度为n的区间的合并结果x上，compose(outer, inner)返回先作用inner再作用outer的标记
+func NewLazySegmentTree[T, L any](arr Array[T], e T, op func(T, T) T,
+	id L, apply func(tag L, x T, n int) T, compose func(outer, inner L) L) SegmentTree[T, L] {
+	return newSegmentTree(arr, &segmentTreeData[T, L]{e: e, op: op, id: id, apply: apply, compose: compose})
+}
+
+func newSegmentTree[T, L any](arr Array[T], d *segmentTreeData[T, L]) SegmentTree[T, L] {
+	d.n = arr.Len()
+	d.log = bits.Len(uint(max(d.n-1, 0)))
+	d.size = 1 << d.log
+	d.tree = make([]T, 2*d.size)
+	for i := range d.tree {
+		d.tree[i] = d.e
+	}
+	copy(d.tree[d.size:], arr.GetSlice())
+	if d.apply != nil {
+		d.lazy = make([]L, d.size)
+		for i := range d.lazy {
+			d.lazy[i] = d.id
+		}
+	}
+	o := SegmentTree[T, L]{d}
+	for k := d.size - 1; k > 0; k-- {
+		o.update(k)
+	}
+	return o
+}
+
+// --------------------辅助函数--------------------
+
+// 支持负值索引，i可以等于Len()
+func (o SegmentTree[T, L]) bound(i int) int {
+	preIdx := i
+	if i < 0 {
+		i += o.d.n
+	}
+	if i < 0 || i > o.d.n {
+		panic("索引超出范围：" + strconv.Itoa(preIdx))
+	}
+	return i
+}
+func (o SegmentTree[T, L]) idx(i int) int {
+	j := o.bound(i)
+	if j == o.d.n {
+		panic("索引超出范围：" + strconv.Itoa(i))
+	}
+	return j
+}
+func (o SegmentTree[T, L]) checkRange(l, r int) (int, int) {
+	l, r = o.bound(l), o.bound(r)
+	if l > r {
+		panic(fmt.Sprintf("区间不合法：[%d, %d)", l, r))
+	}
+	return l, r
+}
+
+// 节点k对应的区间长度
+func (o SegmentTree[T, L]) nodeLen(k int) int { return o.d.size >> (bits.Len(uint(k)) - 1) }
+
+func (o SegmentTree[T, L]) update(k int) { o.d.tree[k] = o.d.op(o.d.tree[2*k], o.d.tree[2*k+1]) }
+func (o SegmentTree[T, L]) allApply(k int, tag L) {
+	o.d.tree[k] = o.d.apply(tag, o.d.tree[k], o.nodeLen(k))
+	if k < o.d.size {
+		o.d.lazy[k] = o.d.compose(tag, o.d.lazy[k])
+	}
+}
+
+// 将节点k的懒标记下传给子节点
+func (o SegmentTree[T, L]) push(k int) {
+	if o.d.lazy == nil {
+		return
+	}
+	o.allApply(2*k, o.d.lazy[k])
+	o.allApply(2*k+1, o.d.lazy[k])
+	o.d.lazy[k] = o.d.id
+}
+
+// 下传包含叶子区间[l, r)边界的所有祖先节点的懒标记，l和r为叶子下标
+func (o SegmentTree[T, L]) pushBorder(l, r int) {
+	for i := o.d.log; i >= 1; i-- {
+		if (l>>i)<<i != l {
+			o.push(l >> i)
+		}
+		if (r>>i)<<i != r {
+			o.push((r - 1) >> i)
+		}
+	}
+}
+
+// --------------------Container接口--------------------
+func (o SegmentTree[T, L]) Len() int       { return o.d.n }
+func (o SegmentTree[T, L]) String() string { return "SegmentTree" + fmt.Sprint(o.ToSlice()) }
+func (o SegmentTree[T, L]) ToSlice() []T { // 会下传所有懒标记，O(n)
+	for k := 1; k < o.d.size; k++ {
+		o.push(k)
+	}
+	return append([]T{}, o.d.tree[o.d.size:o.d.size+o.d.n]...)
+}
+func (o SegmentTree[T, L]) Clone() SegmentTree[T, L] {
+	d := *o.d
+	d.tree = append([]T{}, d.tree...)
+	if d.lazy != nil {
+		d.lazy = append([]L{}, d.lazy...)
+	}
+	return SegmentTree[T, L]{&d}
+}
+
+// --------------------基本操作--------------------
+
+// 第i个元素，O(logn)
+func (o SegmentTree[T, L]) Get(i int) T {
+	p := o.idx(i) + o.d.size
+	for j := o.d.log; j >= 1; j-- {
+		o.push(p >> j)
+	}
+	return o.d.tree[p]
+}
+
+// 将第i个元素设置为x，O(logn)
+func (o SegmentTree[T, L]) Update(i int, x T) SegmentTree[T, L] {
+	p := o.idx(i) + o.d.size
+	for j := o.d.log; j >= 1; j-- {
+		o.push(p >> j)
+	}
+	o.d.tree[p] = x
+	for j := 1; j <= o.d.log; j++ {
+		o.update(p >> j)
+	}
+	return o
+}
+
+// 区间[l, r)的合并结果，空区间返回单位元，O(logn)
+func (o SegmentTree[T, L]) Query(l, r int) T {
+	l, r = o.checkRange(l, r)
+	if l == r {
+		return o.d.e
+	}
+	l, r = l+o.d.size, r+o.d.size
+	o.pushBorder(l, r)
+	sml, smr := o.d.e, o.d.e
+	for ; l < r; l, r = l>>1, r>>1 {
+		if l&1 == 1 {
+			sml = o.d.op(sml, o.d.tree[l])
+			l++
+		}
+		if r&1 == 1 {
+			r--
+			smr = o.d.op(o.d.tree[r], smr)
+		}
+	}
+	return o.d.op(sml, smr)
+}
+
+// 所有元素的合并结果，O(1)
+func (o SegmentTree[T, L]) QueryAll() T { return o.d.tree[1] }
+
+// 将标记作用到区间[l, r)的每个元素上，不支持区间修改时会panic，O(logn)
+func (o SegmentTree[T, L]) RangeApply(l, r int, tag L) SegmentTree[T, L] {
+	if o.d.lazy == nil {
+		panic("线段树不支持区间修改")
+	}
+	l, r = o.checkRange(l, r)
+	if l == r {
+		return o
+	}
+	l, r = l+o.d.size, r+o.d.size
+	o.pushBorder(l, r)
+	for l2, r2 := l, r; l2 < r2; l2, r2 = l2>>1, r2>>1 {
+		if l2&1 == 1 {
+			o.allApply(l2, tag)
+			l2++
+		}
+		if r2&1 == 1 {
+			r2--
+			o.allApply(r2, tag)
+		}
+	}
+	for i := 1; i <= o.d.log; i++ {
+		if (l>>i)<<i != l {
+			o.update(l >> i)
+		}
+		if (r>>i)<<i != r {
+			o.update((r - 1) >> i)
+		}
+	}
+	return o
+}
+
+// --------------------二分查找--------------------
+
+// 最大的r，使得f(Query(l, r))为true，要求f(e)为true，且f在区间扩大时具有单调性，O(logn)
+func (o SegmentTree[T, L]) MaxRight(l int, f func(T) bool) int {
+	l = o.bound(l)
+	if l == o.d.n {
+		return o.d.n
+	}
+	l += o.d.size
+	for i := o.d.log; i >= 1; i-- {
+		o.push(l >> i)
+	}
+	sm := o.d.e
+	for {
+		for l%2 == 0 {
+			l >>= 1
+		}
+		if !f(o.d.op(sm, o.d.tree[l])) {
+			for l < o.d.size {
+				o.push(l)
+				l = 2 * l
+				if res := o.d.op(sm, o.d.tree[l]); f(res) {
+					sm = res
+					l++
+				}
+			}
+			return l - o.d.size
+		}
+		sm = o.d.op(sm, o.d.tree[l])
+		l++
+		if l&-l == l {
+			return o.d.n
+		}
+	}
+}
+
+// 最小的l，使得f(Query(l, r))为true，要求f(e)为true，且f在区间扩大时具有单调性，O(logn)
+func (o SegmentTree[T, L]) MinLeft(r int, f func(T) bool) int {
+	r = o.bound(r)
+	if r == 0 {
+		return 0
+	}
+	r += o.d.size
+	for i := o.d.log; i >= 1; i-- {
+		o.push((r - 1) >> i)
+	}
+	sm := o.d.e
+	for {
+		r--
+		for r > 1 && r%2 == 1 {
+			r >>= 1
+		}
+		if !f(o.d.op(o.d.tree[r], sm)) {
+			for r < o.d.size {
+				o.push(r)
+				r = 2*r + 1
+				if res := o.d.op(o.d.tree[r], sm); f(res) {
+					sm = res
+					r--
+				}
+			}
+			return r + 1 - o.d.size
+		}
+		sm = o.d.op(o.d.tree[r], sm)
+		if r&-r == r {
+			return 0
+		}
+	}
+}
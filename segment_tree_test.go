@@ -0,0 +1,137 @@
+package gods
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test线段树(t *testing.T) {
+	gcd := func(a, b int) int {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	g := NewSegmentTree(NewArrayFromSlice([]int{12, 18, 24, 7, 14}), 0, gcd)
+	fmt.Println(g, "[0,3)的gcd：", g.Query(0, 3), "[-2,5)的gcd：", g.Query(-2, 5))
+	if g.Query(0, 3) != 6 || g.Query(-2, 5) != 7 || g.QueryAll() != 1 || g.Query(2, 2) != 0 {
+		t.Errorf("gcd查询错误")
+	}
+	g.Update(3, 6)
+	if g.QueryAll() != 2 || g.Get(-2) != 6 {
+		t.Errorf("Update错误")
+	}
+
+	// 最小值及其索引
+	type minIdx struct{ v, i int }
+	arr := NewArrayFromSlice([]minIdx{{5, 0}, {2, 1}, {8, 2}, {2, 3}, {9, 4}})
+	m := NewSegmentTree(arr, minIdx{math.MaxInt, -1}, func(a, b minIdx) minIdx {
+		if b.v < a.v {
+			return b
+		}
+		return a
+	})
+	if x := m.Query(2, 5); x != (minIdx{2, 3}) {
+		t.Errorf("最小值及其索引错误：%v", x)
+	}
+
+	// 二分查找：前缀和不超过10的最长区间
+	sum := NewSegmentTree(NewArrayFromSlice([]int{3, 1, 4, 1, 5, 9, 2, 6}), 0, func(a, b int) int { return a + b })
+	le10 := func(x int) bool { return x <= 10 }
+	if r := sum.MaxRight(0, le10); r != 4 {
+		t.Errorf("MaxRight错误：%d", r)
+	}
+	if l := sum.MinLeft(8, le10); l != 6 {
+		t.Errorf("MinLeft错误：%d", l)
+	}
+	if r := sum.MaxRight(8, le10); r != 8 {
+		t.Errorf("MaxRight边界错误：%d", r)
+	}
+}
+
+func Test懒标记线段树(t *testing.T) {
+	// 区间加，区间求和
+	add := NewLazySegmentTree(NewArrayFromSlice([]int{1, 2, 3, 4, 5}), 0, func(a, b int) int { return a + b },
+		0, func(tag, x, n int) int { return x + tag*n }, func(outer, inner int) int { return outer + inner })
+	add.RangeApply(1, 4, 10)
+	fmt.Println(add, "总和：", add.QueryAll())
+	if !slices.Equal(add.ToSlice(), []int{1, 12, 13, 14, 5}) || add.Query(0, 2) != 13 || add.QueryAll() != 45 {
+		t.Errorf("区间加错误")
+	}
+
+	// 区间赋值，区间最大值，与暴力结果比较
+	type assign struct {
+		v  int
+		ok bool
+	}
+	n := 37
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(100)
+	}
+	seg := NewLazySegmentTree(NewArrayFromSlice(slices.Clone(data)), math.MinInt, func(a, b int) int { return max(a, b) },
+		assign{}, func(tag assign, x, _ int) int {
+			if tag.ok {
+				return tag.v
+			}
+			return x
+		}, func(outer, inner assign) assign {
+			if outer.ok {
+				return outer
+			}
+			return inner
+		})
+	for range 3000 {
+		l := rand.Intn(n + 1)
+		r := l + rand.Intn(n+1-l)
+		switch rand.Intn(4) {
+		case 0:
+			x := rand.Intn(100)
+			seg.RangeApply(l, r, assign{x, true})
+			for i := l; i < r; i++ {
+				data[i] = x
+			}
+		case 1:
+			i, x := rand.Intn(n), rand.Intn(100)
+			seg.Update(i, x)
+			data[i] = x
+		case 2:
+			limit := rand.Intn(100)
+			f := func(x int) bool { return x < limit }
+			wantR := l
+			for wantR < n && data[wantR] < limit {
+				wantR++
+			}
+			wantL := r
+			for wantL > 0 && data[wantL-1] < limit {
+				wantL--
+			}
+			if seg.MaxRight(l, f) != wantR || seg.MinLeft(r, f) != wantL {
+				t.Fatalf("二分查找错误：l=%d r=%d limit=%d", l, r, limit)
+			}
+		default:
+			want := math.MinInt
+			for _, x := range data[l:r] {
+				want = max(want, x)
+			}
+			if seg.Query(l, r) != want {
+				t.Fatalf("Query(%d, %d)错误", l, r)
+			}
+		}
+	}
+	clone := seg.Clone()
+	seg.RangeApply(0, n, assign{-1, true})
+	if !slices.Equal(clone.ToSlice(), data) || seg.QueryAll() != -1 {
+		t.Errorf("Clone错误")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("不支持区间修改时RangeApply应panic")
+		}
+	}()
+	NewSegmentTree(NewArray[int](), 0, func(a, b int) int { return a + b }).RangeApply(0, 0, struct{}{})
+}
@@ -1,44 +1,37 @@
 package gods
 
 import (
-	"container/list"
+	"cmp"
 	"fmt"
-	"reflect"
+	"iter"
+	"math/bits"
 	"slices"
+	"strconv"
 )
 
 // ===================================Heap===================================
 
 type Heap[T any] struct {
 	data   *[]T
-	less   func(T, T) bool // 保证所有父元素j和子元素i满足less(j,i)
-	maxCap int             // 支持TopK的堆
+	less   func(T, T) bool  // 保证所有父元素j和子元素i满足less(j,i)
+	maxCap int              // 支持TopK的堆
+	onMove func(x T, i int) // 元素移动到位置i时调用，用于记录元素的位置
 }
 
 func NewHeap[T any]() Heap[T] {
-	// 对可使用<=比较的类型使用自然排序，构建最小堆
+	// 对整数、浮点数和字符串类型（包括自定义类型）使用自然排序，构建最小堆，比较时使用反射
 	var less func(T, T) bool
-	switch any(*new(T)).(type) {
-	case int, int8, int16, int32, int64:
-		less = func(a T, b T) bool {
-			return reflect.ValueOf(a).Int() <= reflect.ValueOf(b).Int()
-		}
-	case uint, uint8, uint16, uint32, uint64:
-		less = func(a T, b T) bool {
-			return reflect.ValueOf(a).Uint() <= reflect.ValueOf(b).Uint()
-		}
-	case float32, float64:
-		less = func(a T, b T) bool {
-			return reflect.ValueOf(a).Float() <= reflect.ValueOf(b).Float()
-		}
-	case string:
-		less = func(a T, b T) bool {
-			return reflect.ValueOf(a).String() <= reflect.ValueOf(b).String()
-		}
+	if c := reflectCmp[T](); c != nil {
+		less = func(a, b T) bool { return c(a, b) <= 0 }
 	}
 	return Heap[T]{data: new([]T), less: less}
 }
 
+// 创建按自然排序的最小堆，不使用反射
+func NewOrderedHeap[T cmp.Ordered]() Heap[T] {
+	return Heap[T]{data: new([]T), less: func(a, b T) bool { return !cmp.Less(b, a) }}
+}
+
 func (o Heap[T]) WithLess(less func(T, T) bool) Heap[T] {
 	if o.Len() != 0 {
 		panic("堆已有元素，不能修改less函数")
@@ -74,6 +67,10 @@ func rc(i int) int  { return 2*i + 2 }
 func (o Heap[T]) swap(i, j int) Heap[T] {
 	d := *o.data
 	d[i], d[j] = d[j], d[i]
+	if o.onMove != nil {
+		o.onMove(d[i], i)
+		o.onMove(d[j], j)
+	}
 	return o
 }
 
@@ -119,14 +116,15 @@ func (o Heap[T]) getSlice() []T {
 
 // --------------------ValueContainer接口--------------------
 func (o Heap[T]) Len() int       { return len(*o.data) }
-func (o Heap[T]) Clear() Heap[T] { o.data = new([]T); return o }
+func (o Heap[T]) Clear() Heap[T] { *o.data = nil; return o } // 不会修改WithSlice传入的切片，之后不再与它共享数据
 func (o Heap[T]) Clone() Heap[T] { return o.WithSlice(append([]T{}, *o.data...)) }
 func (o Heap[T]) String() string { return "Heap" + fmt.Sprint(*o.data) }
-func (o Heap[T]) ForEach(f func(T)) { // 按堆弹出顺序遍历
+func (o Heap[T]) ForEach(f func(T)) Heap[T] { // 按堆弹出顺序遍历
 	h := o.Clone()
 	for h.Len() > 0 {
 		f(h.Pop())
 	}
+	return o
 }
 func (o Heap[T]) ToSlice() []T { // 转换成堆弹出顺序的切片
 	res := make([]T, 0, o.Len())
@@ -134,6 +132,29 @@ func (o Heap[T]) ToSlice() []T { // 转换成堆弹出顺序的切片
 	return res
 }
 
+// 按堆弹出顺序惰性遍历，不复制整个堆，遍历k个元素的代价为O(klogk)，遍历过程中不能修改堆
+func (o Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		d, n := o.getData()
+		if n == 0 {
+			return
+		}
+		// 用索引构成的辅助堆，每次弹出剩余元素中的最小者，并加入其孩子
+		idx := NewHeap[int]().WithLess(func(i, j int) bool { return o.less(d[i], d[j]) }).push(0)
+		for idx.Len() > 0 {
+			i := idx.Pop()
+			if !yield(d[i]) {
+				return
+			}
+			for _, j := range []int{lc(i), rc(i)} {
+				if j < n {
+					idx.push(j)
+				}
+			}
+		}
+	}
+}
+
 // --------------------Heap接口--------------------
 
 // 基本操作
@@ -145,12 +166,24 @@ func (o Heap[T]) Push(x T) Heap[T] {
 	return o.push(x)
 }
 func (o Heap[T]) Pop() T {
-	d, n := o.getData()
-	x := d[0]
-	d[0], *o.data = d[n-1], d[:n-1]
-	o.down(0)
+	x := o.Peek()
+	o.Del(0)
 	return x
 }
+
+// 堆为空时返回false，不会panic
+func (o Heap[T]) TryPop() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Pop(), true
+}
+func (o Heap[T]) TryPeek() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Peek(), true
+}
 func (o Heap[T]) push(x T) Heap[T] {
 	d, n := o.getData()
 	*o.data = append(d, x)
@@ -163,9 +196,13 @@ func (o Heap[T]) Set(i int, x T) Heap[T] {
 	return o.up(i).down(i)
 }
 func (o Heap[T]) Del(i int) Heap[T] {
-	d, n := o.getData()
-	d[i], *o.data = d[n-1], d[:n-1]
-	return o.down(i)
+	n := o.Len()
+	o.swap(i, n-1)
+	*o.data = (*o.data)[:n-1]
+	if i < n-1 { // 移到位置i的元素可能需要上浮或下沉
+		o.up(i).down(i)
+	}
+	return o
 }
 func (o Heap[T]) PushTopK(x T, k int) Heap[T] {
 	if o.Len() < k {
@@ -192,17 +229,24 @@ func (o Stack[T]) GetSlice() []T { return *o.data }
 // --------------------ValueContainer接口--------------------
 func (o Stack[T]) Len() int        { return len(*o.data) }
 func (o Stack[T]) String() string  { return "Stack" + fmt.Sprint(*o.data) }
-func (o Stack[T]) Clear() Stack[T] { o.data = new([]T); return o }
+func (o Stack[T]) Clear() Stack[T] { *o.data = nil; return o } // 不会修改WithSlice传入的切片，之后不再与它共享数据
 func (o Stack[T]) Clone() Stack[T] { return o.WithSlice(append([]T{}, *o.data...)) }
-func (o Stack[T]) ForEach(f func(T)) {
+func (o Stack[T]) ForEach(f func(T)) Stack[T] {
 	d, n := o.getData()
 	d = slices.Clone(d) // 支持边遍历边修改
 	for i := n - 1; i >= 0; i-- {
 		f(d[i])
 	}
+	return o
 }
 func (o Stack[T]) ToSlice() []T { return append([]T{}, *o.data...) }
 
+// 从栈顶到栈底遍历
+func (o Stack[T]) All() iter.Seq[T] { return seqValues(slices.Backward(*o.data)) }
+
+// 从栈底到栈顶遍历
+func (o Stack[T]) Backward() iter.Seq[T] { return slices.Values(*o.data) }
+
 // --------------------Stack接口--------------------
 
 // 基本方法
@@ -218,6 +262,20 @@ func (o Stack[T]) Peek() T {
 	return d[n-1]
 }
 
+// 栈为空时返回false，不会panic
+func (o Stack[T]) TryPop() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Pop(), true
+}
+func (o Stack[T]) TryPeek() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.Peek(), true
+}
+
 // 单调栈
 func (o Stack[T]) PopUntil(f func(T) bool) Stack[T] {
 	for o.Len() > 0 && !f(o.Peek()) {
@@ -228,37 +286,109 @@ func (o Stack[T]) PopUntil(f func(T) bool) Stack[T] {
 
 // ===================================双向队列===================================
 
+// 基于可增长的环形数组实现的双向队列，支持O(1)的随机访问
 type Queue[T any] struct {
-	l *list.List
+	d *queueData[T]
+}
+type queueData[T any] struct {
+	buf  []T // 长度为0或2的幂
+	head int // 队首元素的位置
+	size int
+}
+
+func NewQueue[T any]() Queue[T] { return Queue[T]{&queueData[T]{}} }
+
+// --------------------辅助函数--------------------
+
+// 第i个元素在buf中的位置
+func (o Queue[T]) pos(i int) int { return (o.d.head + i) & (len(o.d.buf) - 1) }
+
+// 支持负值索引
+func (o Queue[T]) idx(i int) int {
+	preIdx := i
+	if i < 0 {
+		i += o.d.size
+	}
+	if i < 0 || i >= o.d.size {
+		panic("索引超出范围：" + strconv.Itoa(preIdx))
+	}
+	return o.pos(i)
+}
+
+// 将容量扩大到至少为n，并把元素移动到数组开头
+func (o Queue[T]) grow(n int) {
+	if n <= len(o.d.buf) {
+		return
+	}
+	buf := make([]T, max(8, 1<<bits.Len(uint(n-1))))
+	o.copyTo(buf)
+	o.d.buf, o.d.head = buf, 0
 }
 
-func NewQueue[T any]() Queue[T] { return Queue[T]{list.New()} }
+// 按顺序把元素复制到dst中
+func (o Queue[T]) copyTo(dst []T) {
+	if o.d.size == 0 {
+		return
+	}
+	if end := o.d.head + o.d.size; end <= len(o.d.buf) {
+		copy(dst, o.d.buf[o.d.head:end])
+	} else {
+		n := copy(dst, o.d.buf[o.d.head:])
+		copy(dst[n:], o.d.buf[:end-len(o.d.buf)])
+	}
+}
 
 // --------------------ValueContainer接口--------------------
-func (o Queue[T]) Len() int { return o.l.Len() }
+func (o Queue[T]) Len() int { return o.d.size }
+func (o Queue[T]) Cap() int { return len(o.d.buf) }
 func (o Queue[T]) Clone() Queue[T] {
 	res := NewQueue[T]()
-	o.ForEach(func(x T) { res.PushBack(x) })
+	res.grow(o.Len())
+	o.copyTo(res.d.buf)
+	res.d.size = o.Len()
 	return res
 }
-func (o Queue[T]) Clear() Queue[T] { *o.l = *list.New(); return o }
-func (o Queue[T]) ForEach(f func(T)) Queue[T] {
-	vals := make([]T, 0)
-	for e := o.l.Front(); e != nil; e = e.Next() {
-		vals = append(vals, e.Value.(T))
-	}
-	for _, x := range vals {
+func (o Queue[T]) Clear() Queue[T] {
+	clear(o.d.buf)
+	o.d.head, o.d.size = 0, 0
+	return o
+}
+func (o Queue[T]) ForEach(f func(T)) Queue[T] { // 遍历的是副本，可以边遍历边修改
+	for _, x := range o.ToSlice() {
 		f(x)
 	}
 	return o
 }
 func (o Queue[T]) ToSlice() []T {
-	res := make([]T, 0, o.Len())
-	o.ForEach(func(x T) { res = append(res, x) })
+	res := make([]T, o.Len())
+	o.copyTo(res)
 	return res
 }
 func (o Queue[T]) String() string { return "Queue" + fmt.Sprint(o.ToSlice()) }
 
+// 从队首到队尾遍历索引和值，遍历过程中不能插入或删除元素
+func (o Queue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range o.Len() {
+			if !yield(i, o.d.buf[o.pos(i)]) {
+				return
+			}
+		}
+	}
+}
+func (o Queue[T]) Values() iter.Seq[T] { return seqValues(o.All()) }
+
+// 从队尾到队首遍历索引和值
+func (o Queue[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := o.Len() - 1; i >= 0; i-- {
+			if !yield(i, o.d.buf[o.pos(i)]) {
+				return
+			}
+		}
+	}
+}
+
 // --------------------Queue接口--------------------
 
 // 单向队列操作
@@ -267,12 +397,120 @@ func (o Queue[T]) Pop() T            { return o.PopFront() }
 func (o Queue[T]) Peek() T           { return o.PeekFront() }
 
 // 双向队列操作
-func (o Queue[T]) PushFront(x T) Queue[T] { o.l.PushFront(x); return o }
-func (o Queue[T]) PushBack(x T) Queue[T]  { o.l.PushBack(x); return o }
-func (o Queue[T]) PopFront() T            { return o.l.Remove(o.l.Front()).(T) }
-func (o Queue[T]) PopBack() T             { return o.l.Remove(o.l.Back()).(T) }
-func (o Queue[T]) PeekFront() T           { return o.l.Front().Value.(T) }
-func (o Queue[T]) PeekBack() T            { return o.l.Back().Value.(T) }
+func (o Queue[T]) PushFront(x T) Queue[T] {
+	o.grow(o.d.size + 1)
+	o.d.head = o.pos(-1)
+	o.d.buf[o.d.head] = x
+	o.d.size++
+	return o
+}
+func (o Queue[T]) PushBack(x T) Queue[T] {
+	o.grow(o.d.size + 1)
+	o.d.buf[o.pos(o.d.size)] = x
+	o.d.size++
+	return o
+}
+func (o Queue[T]) PopFront() T {
+	if o.Len() == 0 {
+		panic("队列为空")
+	}
+	x := o.d.buf[o.d.head]
+	o.d.buf[o.d.head] = *new(T)
+	o.d.head = o.pos(1)
+	o.d.size--
+	return x
+}
+func (o Queue[T]) PopBack() T {
+	if o.Len() == 0 {
+		panic("队列为空")
+	}
+	i := o.pos(o.d.size - 1)
+	x := o.d.buf[i]
+	o.d.buf[i] = *new(T)
+	o.d.size--
+	return x
+}
+func (o Queue[T]) PeekFront() T {
+	if o.Len() == 0 {
+		panic("队列为空")
+	}
+	return o.d.buf[o.d.head]
+}
+func (o Queue[T]) PeekBack() T {
+	if o.Len() == 0 {
+		panic("队列为空")
+	}
+	return o.d.buf[o.pos(o.d.size-1)]
+}
+
+// 随机访问，支持负值索引
+func (o Queue[T]) Get(i int) T             { return o.d.buf[o.idx(i)] }
+func (o Queue[T]) Set(i int, x T) Queue[T] { o.d.buf[o.idx(i)] = x; return o }
+
+// 向后循环移动n位，即将队尾的n个元素移到队首，n为负数时将队首的-n个元素移到队尾
+func (o Queue[T]) Rotate(n int) Queue[T] {
+	size := o.Len()
+	if size <= 1 {
+		return o
+	}
+	if n %= size; n < 0 {
+		n += size
+	}
+	if size == len(o.d.buf) { // 数组已满，只需移动队首位置
+		o.d.head = o.pos(-n)
+		return o
+	}
+	if n <= size/2 {
+		for range n {
+			o.PushFront(o.PopBack())
+		}
+	} else {
+		for range size - n {
+			o.PushBack(o.PopFront())
+		}
+	}
+	return o
+}
+
+// 确保容量至少为n，之后队列长度不超过n时不会重新分配内存
+func (o Queue[T]) Reserve(n int) Queue[T] { o.grow(n); return o }
+
+// 队列为空时返回false，不会panic
+func (o Queue[T]) TryPop() (T, bool)  { return o.TryPopFront() }
+func (o Queue[T]) TryPeek() (T, bool) { return o.TryPeekFront() }
+func (o Queue[T]) TryPopFront() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PopFront(), true
+}
+func (o Queue[T]) TryPopBack() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PopBack(), true
+}
+func (o Queue[T]) TryPeekFront() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PeekFront(), true
+}
+func (o Queue[T]) TryPeekBack() (T, bool) {
+	if o.Len() == 0 {
+		return *new(T), false
+	}
+	return o.PeekBack(), true
+}
+func (o Queue[T]) TryGet(i int) (T, bool) {
+	if i < 0 {
+		i += o.Len()
+	}
+	if i < 0 || i >= o.Len() {
+		return *new(T), false
+	}
+	return o.d.buf[o.pos(i)], true
+}
 
 // 单调队列
 func (o Queue[T]) PopUntil(f func(T) bool) Queue[T] { return o.PopFrontUntil(f) }
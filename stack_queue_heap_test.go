@@ -1,7 +1,10 @@
 package gods
 
 import (
+	"container/list"
 	"fmt"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -27,6 +30,23 @@ func Test栈(t *testing.T) {
 	fmt.Println(s)
 }
 
+func Test栈和堆清空(t *testing.T) {
+	data := []int{3, 1, 2}
+	s := NewStack[int]().WithSlice(data)
+	s.Clear()
+	if s.Len() != 0 || s.Push(4).Peek() != 4 || !slices.Equal(data, []int{3, 1, 2}) {
+		t.Errorf("Stack.Clear错误：%v %v", s, data)
+	}
+
+	data = []int{3, 1, 2}
+	h := NewHeap[int]().WithSlice(data)
+	heapified := slices.Clone(data) // WithSlice会原地建堆
+	h.Clear()
+	if h.Len() != 0 || h.Push(4).Peek() != 4 || !slices.Equal(data, heapified) {
+		t.Errorf("Heap.Clear错误：%v %v", h, data)
+	}
+}
+
 func Test队列(t *testing.T) {
 	q := NewQueue[int]()
 	for i := 0; i < 10; i++ {
@@ -37,3 +57,168 @@ func Test队列(t *testing.T) {
 	q.PopUntil(func(x int) bool { return x >= 5 })
 	fmt.Println(q)
 }
+
+func Test队列清空(t *testing.T) {
+	q := NewQueue[int]().Push(1).Push(2)
+	q.Clear()
+	if q.Len() != 0 || q.Push(3).Push(4).Len() != 2 || !slices.Equal(q.ToSlice(), []int{3, 4}) {
+		t.Errorf("Queue.Clear错误：%v", q)
+	}
+}
+
+func Test迭代器遍历(t *testing.T) {
+	h := NewHeap[int]()
+	for _, x := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(x)
+	}
+	if got := slices.Collect(h.All()); !slices.Equal(got, []int{1, 2, 3, 5, 8, 9}) {
+		t.Errorf("Heap.All = %v", got)
+	}
+	for x := range h.All() { // 提前终止
+		if x > 2 {
+			break
+		}
+		fmt.Println(x)
+	}
+
+	s := NewStack[int]().Push(1).Push(2).Push(3)
+	fmt.Println("栈顶到栈底：", slices.Collect(s.All()))
+
+	q := NewQueue[int]().Push(1).Push(2).Push(3)
+	fmt.Println("队列逆序：", slices.Collect(seqValues(q.Backward())))
+}
+
+func Test栈队列堆Try操作(t *testing.T) {
+	s, q, h := NewStack[int](), NewQueue[int](), NewHeap[int]()
+	if _, ok := s.TryPop(); ok {
+		t.Errorf("空栈TryPop应返回false")
+	}
+	if _, ok := q.TryPeekBack(); ok {
+		t.Errorf("空队列TryPeekBack应返回false")
+	}
+	if _, ok := h.TryPeek(); ok {
+		t.Errorf("空堆TryPeek应返回false")
+	}
+	s.Push(0)
+	q.PushBack(1).PushBack(2)
+	h.Push(3)
+	v1, ok1 := s.TryPop()
+	v2, ok2 := q.TryPopBack()
+	v3, ok3 := h.TryPop()
+	fmt.Println(v1, ok1, v2, ok2, v3, ok3)
+	if v1 != 0 || !ok1 || v2 != 2 || !ok2 || v3 != 3 || !ok3 {
+		t.Errorf("Try操作结果错误")
+	}
+}
+
+type score int
+
+func Test有序堆(t *testing.T) {
+	h1 := NewOrderedHeap[score]()
+	h2 := NewHeap[score]() // 自定义类型也能使用反射的自然排序
+	for _, x := range []score{5, 1, 4, 2, 3} {
+		h1.Push(x)
+		h2.Push(x)
+	}
+	fmt.Println(h1.ToSlice(), h2.ToSlice())
+	if !slices.Equal(h1.ToSlice(), []score{1, 2, 3, 4, 5}) || !slices.Equal(h2.ToSlice(), h1.ToSlice()) {
+		t.Errorf("堆的弹出顺序错误")
+	}
+}
+
+func benchmarkHeap(b *testing.B, h Heap[int]) {
+	data := rand.Perm(1000)
+	for b.Loop() {
+		for _, x := range data {
+			h.Push(x)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+func BenchmarkHeap反射(b *testing.B) { benchmarkHeap(b, NewHeap[int]()) }
+func BenchmarkHeap有序(b *testing.B) { benchmarkHeap(b, NewOrderedHeap[int]()) }
+
+func Test环形数组队列(t *testing.T) {
+	q := NewQueue[int]()
+	for i := range 5 {
+		q.PushBack(i).PushFront(-i - 1)
+	}
+	fmt.Println(q, "容量：", q.Cap())
+	if !slices.Equal(q.ToSlice(), []int{-5, -4, -3, -2, -1, 0, 1, 2, 3, 4}) {
+		t.Errorf("插入顺序错误：%v", q)
+	}
+	if q.Get(0) != -5 || q.Get(-1) != 4 || q.Set(1, 40).Get(1) != 40 {
+		t.Errorf("随机访问错误")
+	}
+	if _, ok := q.TryGet(10); ok {
+		t.Errorf("TryGet越界应返回false")
+	}
+
+	q.Rotate(3)
+	fmt.Println("向后循环移动3位：", q)
+	if !slices.Equal(q.ToSlice(), []int{2, 3, 4, -5, 40, -3, -2, -1, 0, 1}) {
+		t.Errorf("Rotate错误：%v", q)
+	}
+	q.Rotate(-3)
+	if q.Get(0) != -5 {
+		t.Errorf("Rotate负数错误：%v", q)
+	}
+
+	// 和切片模拟的结果对比
+	q = NewQueue[int]().Reserve(100)
+	if q.Cap() != 128 {
+		t.Errorf("Reserve容量错误：%v", q.Cap())
+	}
+	var want []int
+	for i := range 1000 {
+		switch rand.Intn(5) {
+		case 0:
+			q.PushFront(i)
+			want = append([]int{i}, want...)
+		case 1, 2:
+			q.PushBack(i)
+			want = append(want, i)
+		case 3:
+			if x, ok := q.TryPopFront(); ok && x != want[0] {
+				t.Fatalf("PopFront错误")
+			} else if ok {
+				want = want[1:]
+			}
+		case 4:
+			if n := rand.Intn(7) - 3; len(want) > 0 {
+				q.Rotate(n)
+				k := ((n % len(want)) + len(want)) % len(want)
+				want = append(want[len(want)-k:], want[:len(want)-k]...)
+			}
+		}
+		if !slices.Equal(q.ToSlice(), want) {
+			t.Fatalf("队列内容错误：%v，期望%v", q, want)
+		}
+	}
+}
+
+// 模拟BFS：每弹出一个元素，插入两个元素，直到处理完n个元素
+func BenchmarkQueueBFS(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		q := NewQueue[int]().Push(0)
+		for i := 0; i < 10000; i++ {
+			x := q.Pop()
+			q.Push(2*x + 1).Push(2*x + 2)
+		}
+	}
+}
+func BenchmarkQueueBFS链表(b *testing.B) { // 旧实现使用的container/list
+	b.ReportAllocs()
+	for b.Loop() {
+		q := list.New()
+		q.PushBack(0)
+		for i := 0; i < 10000; i++ {
+			x := q.Remove(q.Front()).(int)
+			q.PushBack(2*x + 1)
+			q.PushBack(2*x + 2)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package gods
 
 import (
+	"cmp"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -190,6 +191,21 @@ func (o Str) EqualFold(other Str) bool { return strings.EqualFold(o.S(), other.S
 // 根据比较结果返回0、-1、1，可以直接使用不等号运算符
 func (o Str) Cmp(other Str) int { return strings.Compare(o.S(), other.S()) }
 
+// 不区分大小写比较，可以作为NewTreeMapFunc等构造函数的比较函数
+// 逐个比较rune的大小写等价类代表，与EqualFold和StrFoldHasher使用相同的折叠规则，返回0当且仅当EqualFold为true
+func (o Str) CmpFold(other Str) int {
+	a, b := o.S(), other.S()
+	for a != "" && b != "" {
+		r1, n1 := utf8.DecodeRuneInString(a)
+		r2, n2 := utf8.DecodeRuneInString(b)
+		if c := cmp.Compare(foldRune(r1), foldRune(r2)); c != 0 {
+			return c
+		}
+		a, b = a[n1:], b[n2:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
 // --------------------分割--------------------
 func (o Str) Split(sep Str) []Str      { return o.SplitN(sep, -1) }
 func (o Str) SplitAfter(sep Str) []Str { return o.SplitAfterN(sep, -1) }
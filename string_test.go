@@ -25,3 +25,21 @@ func Test字符串包装类(t *testing.T) {
 		fmt.Printf("%c\n", r)
 	})
 }
+
+func Test不区分大小写比较(t *testing.T) {
+	for _, c := range [][2]Str{{"ς", "σ"}, {"ΣΑΣ", "σας"}, {"Hello", "hELLO"}, {"K", "K"}, {"ab", "aB"}} {
+		if !c[0].EqualFold(c[1]) || c[0].CmpFold(c[1]) != 0 || c[1].CmpFold(c[0]) != 0 {
+			t.Errorf("%q和%q应相等", c[0], c[1])
+		}
+	}
+	for _, c := range [][2]Str{{"a", "B"}, {"ab", "abc"}, {"", "a"}, {"σ", "τ"}} {
+		if c[0].EqualFold(c[1]) || c[0].CmpFold(c[1]) >= 0 || c[1].CmpFold(c[0]) <= 0 {
+			t.Errorf("%q应小于%q", c[0], c[1])
+		}
+	}
+	s := NewTreeSetFunc(Str.CmpFold).Add("σας").Add("ΣΑΣ").Add("ςας")
+	fmt.Println(s)
+	if s.Len() != 1 {
+		t.Errorf("有序集合中的大小写等价字符串应合并：%v", s)
+	}
+}
@@ -0,0 +1,441 @@
+package gods
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ===================================并发安全的哈希表===================================
+
+// 使用读写锁保护的HashMap，方法集和HashMap相同，并提供原子的组合操作
+// 遍历时先复制快照再调用回调函数，回调函数中可以修改映射
+type SyncHashMap[K comparable, V any] struct {
+	m  HashMap[K, V]
+	mu *sync.RWMutex
+}
+
+func NewSyncHashMap[K comparable, V any]() SyncHashMap[K, V] {
+	return SyncHashMap[K, V]{m: NewHashMap[K, V](), mu: &sync.RWMutex{}}
+}
+
+// 需要在其他协程使用之前调用
+func (o SyncHashMap[K, V]) WithFactory(factory func() V) SyncHashMap[K, V] {
+	o.m = o.m.WithFactory(factory)
+	return o
+}
+
+// --------------------Container接口--------------------
+
+func (o SyncHashMap[K, V]) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.m.Len()
+}
+func (o SyncHashMap[K, V]) Clear() SyncHashMap[K, V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.m.Clear()
+	return o
+}
+func (o SyncHashMap[K, V]) Clone() SyncHashMap[K, V] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return SyncHashMap[K, V]{m: o.m.Clone(), mu: &sync.RWMutex{}}
+}
+func (o SyncHashMap[K, V]) String() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return strings.Replace(o.m.String(), "HashMap", "SyncHashMap", 1)
+}
+
+// --------------------Map接口--------------------
+// 遍历和转换
+func (o SyncHashMap[K, V]) ForEach(f func(K, V)) SyncHashMap[K, V] {
+	for k, v := range o.ToMap() {
+		f(k, v)
+	}
+	return o
+}
+func (o SyncHashMap[K, V]) ToMap() map[K]V {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.m.ToMap()
+}
+
+// 迭代器，遍历调用时的快照，顺序不确定
+func (o SyncHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range o.ToMap() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+func (o SyncHashMap[K, V]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o SyncHashMap[K, V]) Values() iter.Seq[V] { return seqValues(o.All()) }
+
+// 基本操作
+func (o SyncHashMap[K, V]) Has(k K) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.m.Has(k)
+}
+func (o SyncHashMap[K, V]) Set(k K, v V) SyncHashMap[K, V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.m.Set(k, v)
+	return o
+}
+func (o SyncHashMap[K, V]) Del(k K) SyncHashMap[K, V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.m.Del(k)
+	return o
+}
+func (o SyncHashMap[K, V]) Get(k K) V {
+	o.mu.RLock()
+	v, ok := o.m.m[k]
+	o.mu.RUnlock()
+	if ok || o.m.factory == nil {
+		return v
+	}
+	o.mu.Lock() // 需要使用工厂函数设置值
+	defer o.mu.Unlock()
+	return o.m.Get(k)
+}
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o SyncHashMap[K, V]) Lookup(k K) (V, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.m.Lookup(k)
+}
+
+// 组合操作，都是原子的
+func (o SyncHashMap[K, V]) GetOr(k K, v V) V {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.m.GetOr(k, v)
+}
+func (o SyncHashMap[K, V]) GetOrSet(k K, v V) V {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.m.GetOrSet(k, v)
+}
+func (o SyncHashMap[K, V]) Extend(other SyncHashMap[K, V]) SyncHashMap[K, V] {
+	m := other.ToMap() // 先复制，避免同时持有两个锁
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	maps.Copy(o.m.m, m)
+	return o
+}
+
+// 整体是原子的，f在持有写锁时执行，不能再访问该映射，否则会死锁
+func (o SyncHashMap[K, V]) DelFunc(f func(K, V) bool) SyncHashMap[K, V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.m.DelFunc(f)
+	return o
+}
+
+// 整体是原子的，f在持有写锁时执行，不能再访问该映射，否则会死锁
+func (o SyncHashMap[K, V]) ReplaceFunc(f func(K, V) V) SyncHashMap[K, V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.m.ReplaceFunc(f)
+	return o
+}
+
+// 根据旧值计算新值，f的参数为旧值及其是否存在，返回新值以及是否保留，不保留时删除该键
+// 返回计算后的值以及是否存在，f在持有写锁时执行，不能再访问该映射
+func (o SyncHashMap[K, V]) Compute(k K, f func(old V, ok bool) (V, bool)) (V, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return computeMap(o.m.m, k, f)
+}
+
+// 当键存在且值等于old时设置为new，V必须是可比较的类型，否则会panic
+func (o SyncHashMap[K, V]) CompareAndSwap(k K, old, new V) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return compareAndSwapMap(o.m.m, k, old, new)
+}
+
+// 当键存在且值等于old时删除，V必须是可比较的类型，否则会panic
+func (o SyncHashMap[K, V]) CompareAndDelete(k K, old V) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return compareAndDeleteMap(o.m.m, k, old)
+}
+
+// 键值列表查询
+func (o SyncHashMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o SyncHashMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
+
+// --------------------原子操作的实现--------------------
+
+func computeMap[K comparable, V any](m map[K]V, k K, f func(V, bool) (V, bool)) (V, bool) {
+	old, ok := m[k]
+	v, keep := f(old, ok)
+	if keep {
+		m[k] = v
+		return v, true
+	}
+	delete(m, k)
+	return *new(V), false
+}
+func compareAndSwapMap[K comparable, V any](m map[K]V, k K, old, new V) bool {
+	if v, ok := m[k]; !ok || any(v) != any(old) {
+		return false
+	}
+	m[k] = new
+	return true
+}
+func compareAndDeleteMap[K comparable, V any](m map[K]V, k K, old V) bool {
+	if v, ok := m[k]; !ok || any(v) != any(old) {
+		return false
+	}
+	delete(m, k)
+	return true
+}
+
+// ===================================分段锁哈希表===================================
+
+const defaultShardCount = 32
+
+type mapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// 按键的哈希值分成多个分段，每个分段使用独立的读写锁，适合写入频繁的场景
+// 单个键的操作是原子的，Len、ForEach等跨分段的操作不保证得到一致的快照
+type ConcurrentHashMap[K comparable, V any] struct {
+	shards  []*mapShard[K, V]
+	seed    maphash.Seed
+	factory func() V
+}
+
+func NewConcurrentHashMap[K comparable, V any]() ConcurrentHashMap[K, V] {
+	return NewConcurrentHashMapN[K, V](defaultShardCount)
+}
+
+// 指定分段个数创建映射
+func NewConcurrentHashMapN[K comparable, V any](n int) ConcurrentHashMap[K, V] {
+	if n <= 0 {
+		panic("分段个数必须大于0")
+	}
+	shards := make([]*mapShard[K, V], n)
+	for i := range shards {
+		shards[i] = &mapShard[K, V]{m: map[K]V{}}
+	}
+	return ConcurrentHashMap[K, V]{shards: shards, seed: maphash.MakeSeed()}
+}
+
+// 需要在其他协程使用之前调用
+func (o ConcurrentHashMap[K, V]) WithFactory(factory func() V) ConcurrentHashMap[K, V] {
+	o.factory = factory
+	return o
+}
+
+// 辅助函数，返回键所在的分段
+func (o ConcurrentHashMap[K, V]) shard(k K) *mapShard[K, V] {
+	return o.shards[maphash.Comparable(o.seed, k)%uint64(len(o.shards))]
+}
+
+// --------------------Container接口--------------------
+
+func (o ConcurrentHashMap[K, V]) Len() int {
+	n := 0
+	for _, s := range o.shards {
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+func (o ConcurrentHashMap[K, V]) Clear() ConcurrentHashMap[K, V] {
+	for _, s := range o.shards {
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+	return o
+}
+func (o ConcurrentHashMap[K, V]) Clone() ConcurrentHashMap[K, V] {
+	res := NewConcurrentHashMapN[K, V](len(o.shards)).WithFactory(o.factory)
+	res.seed = o.seed
+	for i, s := range o.shards {
+		s.mu.RLock()
+		res.shards[i].m = maps.Clone(s.m)
+		s.mu.RUnlock()
+	}
+	return res
+}
+func (o ConcurrentHashMap[K, V]) String() string {
+	return "ConcurrentHashMap" + fmt.Sprint(o.ToMap())[3:]
+}
+
+// --------------------Map接口--------------------
+// 遍历和转换
+func (o ConcurrentHashMap[K, V]) ForEach(f func(K, V)) ConcurrentHashMap[K, V] {
+	for k, v := range o.All() {
+		f(k, v)
+	}
+	return o
+}
+func (o ConcurrentHashMap[K, V]) ToMap() map[K]V {
+	res := make(map[K]V)
+	for _, s := range o.shards {
+		s.mu.RLock()
+		maps.Copy(res, s.m)
+		s.mu.RUnlock()
+	}
+	return res
+}
+
+// 迭代器，逐个分段复制快照后遍历，顺序不确定
+func (o ConcurrentHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, s := range o.shards {
+			s.mu.RLock()
+			m := maps.Clone(s.m)
+			s.mu.RUnlock()
+			for k, v := range m {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+func (o ConcurrentHashMap[K, V]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o ConcurrentHashMap[K, V]) Values() iter.Seq[V] { return seqValues(o.All()) }
+
+// 基本操作
+func (o ConcurrentHashMap[K, V]) Has(k K) bool {
+	s := o.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.m[k]
+	return ok
+}
+func (o ConcurrentHashMap[K, V]) Set(k K, v V) ConcurrentHashMap[K, V] {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[k] = v
+	return o
+}
+func (o ConcurrentHashMap[K, V]) Del(k K) ConcurrentHashMap[K, V] {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, k)
+	return o
+}
+func (o ConcurrentHashMap[K, V]) Get(k K) V {
+	s := o.shard(k)
+	s.mu.RLock()
+	v, ok := s.m[k]
+	s.mu.RUnlock()
+	if ok || o.factory == nil {
+		return v
+	}
+	s.mu.Lock() // 需要使用工厂函数设置值
+	defer s.mu.Unlock()
+	if v, ok := s.m[k]; ok {
+		return v
+	}
+	v = o.factory()
+	s.m[k] = v
+	return v
+}
+
+// 查找键对应的值，不存在时返回false，不会调用factory函数
+func (o ConcurrentHashMap[K, V]) Lookup(k K) (V, bool) {
+	s := o.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[k]
+	return v, ok
+}
+
+// 组合操作，单个键的操作都是原子的
+func (o ConcurrentHashMap[K, V]) GetOr(k K, v V) V {
+	s := o.shard(k)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if old, ok := s.m[k]; ok {
+		return old
+	}
+	return v
+}
+func (o ConcurrentHashMap[K, V]) GetOrSet(k K, v V) V {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.m[k]; ok {
+		return old
+	}
+	s.m[k] = v
+	return v
+}
+func (o ConcurrentHashMap[K, V]) Extend(other ConcurrentHashMap[K, V]) ConcurrentHashMap[K, V] {
+	for k, v := range other.All() {
+		o.Set(k, v)
+	}
+	return o
+}
+
+// 逐个分段执行，每个分段内是原子的，f在持有分段的写锁时执行，不能再访问该映射，否则可能死锁
+func (o ConcurrentHashMap[K, V]) DelFunc(f func(K, V) bool) ConcurrentHashMap[K, V] {
+	for _, s := range o.shards {
+		s.mu.Lock()
+		maps.DeleteFunc(s.m, f)
+		s.mu.Unlock()
+	}
+	return o
+}
+
+// 参见DelFunc，f在持有分段的写锁时执行，不能再访问该映射
+func (o ConcurrentHashMap[K, V]) ReplaceFunc(f func(K, V) V) ConcurrentHashMap[K, V] {
+	for _, s := range o.shards {
+		s.mu.Lock()
+		for k, v := range s.m {
+			s.m[k] = f(k, v)
+		}
+		s.mu.Unlock()
+	}
+	return o
+}
+
+// 参见SyncHashMap的同名方法，f在持有分段的写锁时执行
+func (o ConcurrentHashMap[K, V]) Compute(k K, f func(old V, ok bool) (V, bool)) (V, bool) {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return computeMap(s.m, k, f)
+}
+func (o ConcurrentHashMap[K, V]) CompareAndSwap(k K, old, new V) bool {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return compareAndSwapMap(s.m, k, old, new)
+}
+func (o ConcurrentHashMap[K, V]) CompareAndDelete(k K, old V) bool {
+	s := o.shard(k)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return compareAndDeleteMap(s.m, k, old)
+}
+
+// 键值列表查询
+func (o ConcurrentHashMap[K, V]) KeySlice() []K   { return slices.Collect(o.Keys()) }
+func (o ConcurrentHashMap[K, V]) ValueSlice() []V { return slices.Collect(o.Values()) }
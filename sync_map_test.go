@@ -0,0 +1,71 @@
+package gods
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func Test并发哈希表(t *testing.T) {
+	m := NewSyncHashMap[int, int]()
+	cm := NewConcurrentHashMapN[int, int](8)
+	wg := sync.WaitGroup{}
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				m.Compute(i%10, func(old int, ok bool) (int, bool) { return old + 1, true })
+				cm.Compute(i%10, func(old int, ok bool) (int, bool) { return old + 1, true })
+				m.GetOrSet(100+g, g)
+				cm.Set(10000+1000*g+i, i)
+				if i > 0 {
+					cm.Del(10000 + 1000*g + i - 1)
+				}
+				_ = m.Len() + cm.Len()
+				for range cm.All() {
+					break
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for i := range 10 {
+		if m.Get(i) != 800 || cm.Get(i) != 800 {
+			t.Errorf("Compute计数错误：%v %v", m.Get(i), cm.Get(i))
+		}
+	}
+	if m.Len() != 18 || cm.Len() != 18 {
+		t.Errorf("长度错误：%v %v", m.Len(), cm.Len())
+	}
+
+	if !m.CompareAndSwap(0, 800, 1) || m.CompareAndSwap(0, 800, 2) || m.Get(0) != 1 {
+		t.Errorf("CompareAndSwap错误")
+	}
+	if !cm.CompareAndDelete(0, 800) || cm.Has(0) {
+		t.Errorf("CompareAndDelete错误")
+	}
+	cm.DelFunc(func(k, v int) bool { return k >= 10 })
+	fmt.Println(m.Len(), cm)
+}
+
+func Test并发哈希表工厂函数(t *testing.T) {
+	m := NewSyncHashMap[string, *int]().WithFactory(func() *int { return new(int) })
+	cm := NewConcurrentHashMap[string, *int]().WithFactory(func() *int { return new(int) })
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				m.Get("a") // 工厂函数只会调用一次
+				cm.Get("a")
+			}
+		}()
+	}
+	wg.Wait()
+	if m.Len() != 1 || cm.Len() != 1 || m.Get("a") == nil {
+		t.Errorf("工厂函数错误")
+	}
+	fmt.Println(m.KeySlice(), cm.KeySlice())
+}
@@ -0,0 +1,251 @@
+package gods
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// ===================================有序映射的视图===================================
+
+// 有序映射中键在指定范围内的部分，由SubMap、HeadMapByKey和TailMapByKey创建，不复制节点
+// 视图和原映射共享同一棵树，修改互相可见，边界的开闭与Java的NavigableMap相同
+// 长度和范围统计为O(logn)，设置范围外的键会panic
+type TreeMapView[K comparable, V any] struct {
+	m      TreeMap[K, V]
+	lo, hi viewBound[K]
+}
+
+// 视图的边界，ok为false时表示没有这一侧的边界
+type viewBound[K any] struct {
+	k         K
+	inclusive bool
+	ok        bool
+}
+
+// 辅助函数，键是否在下界之上、上界之下
+func (o TreeMapView[K, V]) aboveLo(k K) bool {
+	if !o.lo.ok {
+		return true
+	}
+	c := o.m.cmp(k, o.lo.k)
+	return c > 0 || o.lo.inclusive && c == 0
+}
+func (o TreeMapView[K, V]) belowHi(k K) bool {
+	if !o.hi.ok {
+		return true
+	}
+	c := o.m.cmp(k, o.hi.k)
+	return c < 0 || o.hi.inclusive && c == 0
+}
+func (o TreeMapView[K, V]) inRange(k K) bool { return o.aboveLo(k) && o.belowHi(k) }
+
+// 与lo和hi表示的范围取交集，得到新的视图
+func (o TreeMapView[K, V]) narrow(lo, hi viewBound[K]) TreeMapView[K, V] {
+	if lo.ok && !o.lo.ok {
+		o.lo = lo
+	} else if lo.ok {
+		if c := o.m.cmp(lo.k, o.lo.k); c > 0 || c == 0 && !lo.inclusive { // 新的下界更高
+			o.lo = lo
+		}
+	}
+	if hi.ok && !o.hi.ok {
+		o.hi = hi
+	} else if hi.ok {
+		if c := o.m.cmp(hi.k, o.hi.k); c < 0 || c == 0 && !hi.inclusive { // 新的上界更低
+			o.hi = hi
+		}
+	}
+	return o
+}
+
+// --------------------Container接口--------------------
+func (o TreeMapView[K, V]) Len() int {
+	below, above := o.m.Len(), 0 // 不超过上界的键的个数，低于下界的键的个数
+	if o.hi.ok {
+		below = o.m.countBelow(o.hi.k, o.hi.inclusive)
+	}
+	if o.lo.ok {
+		above = o.m.countBelow(o.lo.k, !o.lo.inclusive)
+	}
+	return max(0, below-above)
+}
+func (o TreeMapView[K, V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "TreeMapView[" + strings.Join(entries, " ") + "]"
+}
+
+// 从原映射中删除视图范围内的所有键，基于拆分与合并，O(logn)
+func (o TreeMapView[K, V]) Clear() TreeMapView[K, V] {
+	var l, r *avlNode[K, V]
+	rest := o.m.root()
+	if o.lo.ok {
+		l, rest = o.m.splitAt(rest, o.lo.k, !o.lo.inclusive)
+	}
+	if o.hi.ok {
+		_, r = o.m.splitAt(rest, o.hi.k, o.hi.inclusive)
+	}
+	o.m.setRoot(o.m.join2(l, r))
+	return o
+}
+
+// 复制视图中的键值对，得到新的映射，O(logn+k)，k为视图的长度
+func (o TreeMapView[K, V]) ToTreeMap() TreeMap[K, V] { return o.m.fromSorted(o.All()) }
+
+// --------------------MapContainer接口--------------------
+
+// 按键升序惰性遍历，遍历过程中不能修改映射
+func (o TreeMapView[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := range o.m.root().ascendWhere(func(node *avlNode[K, V]) bool { return o.aboveLo(node.k) }) {
+			if !o.belowHi(node.k) || !yield(node.k, node.v) {
+				return
+			}
+		}
+	}
+}
+func (o TreeMapView[K, V]) Keys() iter.Seq[K]   { return seqKeys(o.All()) }
+func (o TreeMapView[K, V]) Values() iter.Seq[V] { return seqValues(o.All()) }
+func (o TreeMapView[K, V]) KeySlice() []K       { return slices.Collect(o.Keys()) }
+func (o TreeMapView[K, V]) ValueSlice() []V     { return slices.Collect(o.Values()) }
+
+// 按键降序惰性遍历
+func (o TreeMapView[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := range o.m.root().descendWhere(func(node *avlNode[K, V]) bool { return o.belowHi(node.k) }) {
+			if !o.aboveLo(node.k) || !yield(node.k, node.v) {
+				return
+			}
+		}
+	}
+}
+
+// 基本操作，范围外的键视为不存在
+func (o TreeMapView[K, V]) Has(k K) bool { return o.inRange(k) && o.m.Has(k) }
+func (o TreeMapView[K, V]) Get(k K) V { // 范围外的键返回零值，不会调用factory函数
+	if !o.inRange(k) {
+		return *new(V)
+	}
+	return o.m.Get(k)
+}
+func (o TreeMapView[K, V]) GetOr(k K, v V) V {
+	if !o.inRange(k) {
+		return v
+	}
+	return o.m.GetOr(k, v)
+}
+func (o TreeMapView[K, V]) Lookup(k K) (V, bool) {
+	if !o.inRange(k) {
+		return *new(V), false
+	}
+	return o.m.Lookup(k)
+}
+
+// 设置原映射中的键值对，键超出视图的范围时会panic
+func (o TreeMapView[K, V]) Set(k K, v V) TreeMapView[K, V] {
+	if !o.inRange(k) {
+		panic(fmt.Sprintf("键超出视图的范围：%v", k))
+	}
+	o.m.Set(k, v)
+	return o
+}
+
+// 删除原映射中的键，范围外的键不做任何操作
+func (o TreeMapView[K, V]) Del(k K) TreeMapView[K, V] {
+	if o.inRange(k) {
+		o.m.Del(k)
+	}
+	return o
+}
+
+// 视图中的第一个和最后一个键值对，视图为空时返回nil，O(logn)
+func (o TreeMapView[K, V]) First() *MapEntry[K, V] {
+	e := o.m.First()
+	if o.lo.ok && o.lo.inclusive {
+		e = o.m.Ceiling(o.lo.k)
+	} else if o.lo.ok {
+		e = o.m.Higher(o.lo.k)
+	}
+	if e == nil || !o.belowHi(e.K) {
+		return nil
+	}
+	return e
+}
+func (o TreeMapView[K, V]) Last() *MapEntry[K, V] {
+	e := o.m.Last()
+	if o.hi.ok && o.hi.inclusive {
+		e = o.m.Floor(o.hi.k)
+	} else if o.hi.ok {
+		e = o.m.Lower(o.hi.k)
+	}
+	if e == nil || !o.aboveLo(e.K) {
+		return nil
+	}
+	return e
+}
+
+// --------------------按键的范围操作--------------------
+// 范围与视图的范围取交集
+
+func (o TreeMapView[K, V]) Range(lo, hi K, loInclusive, hiInclusive bool) iter.Seq2[K, V] {
+	return o.SubMap(lo, hi, loInclusive, hiInclusive).All()
+}
+func (o TreeMapView[K, V]) CountRange(lo, hi K, loInclusive, hiInclusive bool) int {
+	return o.SubMap(lo, hi, loInclusive, hiInclusive).Len()
+}
+func (o TreeMapView[K, V]) SubMap(lo, hi K, loInclusive, hiInclusive bool) TreeMapView[K, V] {
+	return o.narrow(viewBound[K]{lo, loInclusive, true}, viewBound[K]{hi, hiInclusive, true})
+}
+func (o TreeMapView[K, V]) HeadMapByKey(hi K, inclusive bool) TreeMapView[K, V] {
+	return o.narrow(viewBound[K]{}, viewBound[K]{hi, inclusive, true})
+}
+func (o TreeMapView[K, V]) TailMapByKey(lo K, inclusive bool) TreeMapView[K, V] {
+	return o.narrow(viewBound[K]{lo, inclusive, true}, viewBound[K]{})
+}
+
+// ===================================有序集合的视图===================================
+
+// 有序集合中元素在指定范围内的部分，由SubSet、HeadSet和TailSet创建，参见TreeMapView
+type TreeSetView[T comparable] struct {
+	m TreeMapView[T, struct{}]
+}
+
+// --------------------ValueContainer接口--------------------
+func (o TreeSetView[T]) Len() int              { return o.m.Len() }
+func (o TreeSetView[T]) String() string        { return "TreeSetView" + fmt.Sprint(o.ToSlice()) }
+func (o TreeSetView[T]) Clear() TreeSetView[T] { o.m.Clear(); return o } // 从原集合中删除范围内的元素
+func (o TreeSetView[T]) ToSlice() []T          { return o.m.KeySlice() }
+func (o TreeSetView[T]) ToTreeSet() TreeSet[T] { return TreeSet[T]{m: o.m.ToTreeMap()} }
+
+// 升序和降序惰性遍历，遍历过程中不能修改集合
+func (o TreeSetView[T]) All() iter.Seq[T]      { return o.m.Keys() }
+func (o TreeSetView[T]) Backward() iter.Seq[T] { return seqKeys(o.m.Backward()) }
+
+// --------------------SetContainer接口--------------------
+func (o TreeSetView[T]) Has(x T) bool           { return o.m.Has(x) }
+func (o TreeSetView[T]) Add(x T) TreeSetView[T] { o.m.Set(x, struct{}{}); return o } // 超出范围时会panic
+func (o TreeSetView[T]) Del(x T) TreeSetView[T] { o.m.Del(x); return o }
+
+// 视图为空时返回false
+func (o TreeSetView[T]) TryFirst() (T, bool) { return o.m.First().key() }
+func (o TreeSetView[T]) TryLast() (T, bool)  { return o.m.Last().key() }
+
+// --------------------按元素的范围操作--------------------
+// 范围与视图的范围取交集
+
+func (o TreeSetView[T]) Range(lo, hi T, loInclusive, hiInclusive bool) iter.Seq[T] {
+	return seqKeys(o.m.Range(lo, hi, loInclusive, hiInclusive))
+}
+func (o TreeSetView[T]) CountRange(lo, hi T, loInclusive, hiInclusive bool) int {
+	return o.m.CountRange(lo, hi, loInclusive, hiInclusive)
+}
+func (o TreeSetView[T]) SubSet(lo, hi T, loInclusive, hiInclusive bool) TreeSetView[T] {
+	o.m = o.m.SubMap(lo, hi, loInclusive, hiInclusive)
+	return o
+}
+func (o TreeSetView[T]) HeadSet(x T) TreeSetView[T] { o.m = o.m.HeadMapByKey(x, true); return o }
+func (o TreeSetView[T]) TailSet(x T) TreeSetView[T] { o.m = o.m.TailMapByKey(x, true); return o }
@@ -0,0 +1,115 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test有序映射视图(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	for i := 0; i < 20; i += 2 {
+		m.Set(i, fmt.Sprint(i))
+	}
+	v := m.SubMap(4, 12, true, false)
+	if v.Len() != 4 || v.First().K != 4 || v.Last().K != 10 || v.Has(12) || v.Get(14) != "" {
+		t.Errorf("SubMap视图错误：%v", v)
+	}
+
+	// 视图和原映射的修改互相可见
+	m.Set(5, "5").Del(4)
+	v.Set(7, "7").Del(10)
+	if !slices.Equal(v.KeySlice(), []int{5, 6, 7, 8}) || !m.Has(7) || m.Has(10) {
+		t.Errorf("视图没有共享原映射：%v %v", v, m)
+	}
+	if got := slices.Collect(seqKeys(v.Backward())); !slices.Equal(got, []int{8, 7, 6, 5}) {
+		t.Errorf("Backward错误：%v", got)
+	}
+
+	// 截取更小的视图时与原来的范围取交集
+	if got := v.TailMapByKey(0, true).HeadMapByKey(6, false).KeySlice(); !slices.Equal(got, []int{5}) {
+		t.Errorf("嵌套视图错误：%v", got)
+	}
+	if v.CountRange(0, 100, true, true) != 4 || v.SubMap(6, 6, false, true).Len() != 0 {
+		t.Errorf("CountRange错误")
+	}
+
+	copied := v.ToTreeMap().Set(100, "x")
+	v.Clear()
+	if v.Len() != 0 || m.Len() != 6 || copied.Len() != 5 || v.First() != nil {
+		t.Errorf("Clear或ToTreeMap错误：%v %v %v", v, m, copied)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("设置范围外的键应panic")
+		}
+	}()
+	m.HeadMapByKey(3, true).Set(3, "3").Set(4, "4")
+}
+
+func Test有序集合视图(t *testing.T) {
+	s := NewTreeSetFromSlice([]int{1, 3, 5, 7, 9})
+	head := s.HeadSet(5)
+	s.Add(4).Add(6)
+	if !slices.Equal(head.ToSlice(), []int{1, 3, 4, 5}) || head.Has(6) {
+		t.Errorf("HeadSet视图错误：%v", head)
+	}
+	head.Del(1).Add(2)
+	if !slices.Equal(s.ToSlice(), []int{2, 3, 4, 5, 6, 7, 9}) {
+		t.Errorf("通过视图修改错误：%v", s)
+	}
+	if first, ok := s.TailSet(8).TryFirst(); !ok || first != 9 {
+		t.Errorf("TailSet视图错误")
+	}
+	if _, ok := s.SubSet(7, 9, false, false).TryLast(); ok {
+		t.Errorf("空视图TryLast应返回false")
+	}
+	s.SubSet(3, 6, false, true).Clear()
+	if !slices.Equal(s.ToSlice(), []int{2, 3, 7, 9}) {
+		t.Errorf("视图Clear错误：%v", s)
+	}
+}
+
+func Test有序映射视图随机(t *testing.T) {
+	bounds := []bool{true, false}
+	randBound := func() (int, bool) { return rand.Intn(30) - 2, bounds[rand.Intn(2)] }
+	for range 500 {
+		m := NewTreeMap[int, int]()
+		for range rand.Intn(30) {
+			k := rand.Intn(25)
+			m.Set(k, k)
+		}
+		// 两次截取，与暴力过滤的结果比较
+		lo1, loInc1 := randBound()
+		hi1, hiInc1 := randBound()
+		lo2, loInc2 := randBound()
+		hi2, hiInc2 := randBound()
+		v := m.SubMap(lo1, hi1, loInc1, hiInc1).SubMap(lo2, hi2, loInc2, hiInc2)
+		in := func(k, lo, hi int, loInc, hiInc bool) bool {
+			return (k > lo || loInc && k == lo) && (k < hi || hiInc && k == hi)
+		}
+		var want []int
+		for k := range m.Keys() {
+			if in(k, lo1, hi1, loInc1, hiInc1) && in(k, lo2, hi2, loInc2, hiInc2) {
+				want = append(want, k)
+			}
+		}
+		if v.Len() != len(want) || !slices.Equal(v.KeySlice(), want) {
+			t.Fatalf("视图错误：%v %v，应为%v", m, v, want)
+		}
+		if e := v.First(); len(want) > 0 && e.K != want[0] || len(want) == 0 && e != nil {
+			t.Fatalf("First错误：%v %v", v, e)
+		}
+		if e := v.Last(); len(want) > 0 && e.K != want[len(want)-1] || len(want) == 0 && e != nil {
+			t.Fatalf("Last错误：%v %v", v, e)
+		}
+		n := m.Len()
+		v.Clear()
+		checkAVL(t, m.root(), m.cmp)
+		if m.Len() != n-len(want) || v.Len() != 0 {
+			t.Fatalf("Clear错误：%v", m)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ===================================字典树===================================
+
+// 以Str为键的字典树，按rune划分节点，遍历时按键的字典序
+// 每个键可以设置权重，用于按权重自动补全
+type Trie[V any] struct {
+	root *trieNode[V]
+	size *int
+}
+
+type trieNode[V any] struct {
+	children  []trieEdge[V] // 按rune升序排列
+	count     int           // 子树中键的个数，包括自身
+	has       bool          // 是否存在以该节点结尾的键
+	val       V
+	weight    float64
+	maxWeight float64 // 子树中键的最大权重，没有键时为负无穷，用于TopK剪枝
+}
+
+type trieEdge[V any] struct {
+	r    rune
+	node *trieNode[V]
+}
+
+func NewTrie[V any]() Trie[V] { return Trie[V]{root: newTrieNode[V](), size: new(int)} }
+
+func newTrieNode[V any]() *trieNode[V] { return &trieNode[V]{maxWeight: math.Inf(-1)} }
+
+// --------------------节点操作--------------------
+
+// 查找子节点的位置，不存在时返回应插入的位置和false
+func (o *trieNode[V]) search(r rune) (int, bool) {
+	return slices.BinarySearchFunc(o.children, r, func(e trieEdge[V], r rune) int { return cmp.Compare(e.r, r) })
+}
+func (o *trieNode[V]) child(r rune) *trieNode[V] {
+	if i, ok := o.search(r); ok {
+		return o.children[i].node
+	}
+	return nil
+}
+func (o *trieNode[V]) deepCopy() *trieNode[V] {
+	res := *o
+	res.children = make([]trieEdge[V], len(o.children))
+	for i, e := range o.children {
+		res.children[i] = trieEdge[V]{e.r, e.node.deepCopy()}
+	}
+	return &res
+}
+
+// 自底向上重新计算path上节点的子树最大权重，某个节点的值不变时，它的祖先也不会变
+func refreshMaxWeight[V any](path []*trieNode[V]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+		m := math.Inf(-1)
+		if node.has {
+			m = node.weight
+		}
+		for _, e := range node.children {
+			m = max(m, e.node.maxWeight)
+		}
+		if m == node.maxWeight {
+			return
+		}
+		node.maxWeight = m
+	}
+}
+
+// 按字典序遍历子树中的键，path为当前节点对应的前缀，会被修改
+func (o *trieNode[V]) walk(path []rune, yield func(Str, *trieNode[V]) bool) bool {
+	if o.has && !yield(Str(string(path)), o) {
+		return false
+	}
+	for _, e := range o.children {
+		if !e.node.walk(append(path, e.r), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// 前缀对应的节点，不存在时返回nil
+func (o Trie[V]) find(prefix Str) *trieNode[V] {
+	if path := o.path(prefix); path != nil {
+		return path[len(path)-1]
+	}
+	return nil
+}
+
+// 从根节点到前缀对应节点的路径，不存在时返回nil
+func (o Trie[V]) path(prefix Str) []*trieNode[V] {
+	path := []*trieNode[V]{o.root}
+	for _, r := range prefix.S() {
+		node := path[len(path)-1].child(r)
+		if node == nil {
+			return nil
+		}
+		path = append(path, node)
+	}
+	return path
+}
+
+// 遍历以prefix开头的键及其节点
+func (o Trie[V]) walk(prefix Str) iter.Seq2[Str, *trieNode[V]] {
+	return func(yield func(Str, *trieNode[V]) bool) {
+		if node := o.find(prefix); node != nil {
+			node.walk([]rune(prefix.S()), yield)
+		}
+	}
+}
+
+// --------------------Container接口--------------------
+func (o Trie[V]) Len() int { return *o.size }
+func (o Trie[V]) String() string {
+	entries := make([]string, 0, o.Len())
+	for k, v := range o.All() {
+		entries = append(entries, fmt.Sprintf("%v:%v", k, v))
+	}
+	return "Trie[" + strings.Join(entries, " ") + "]"
+}
+func (o Trie[V]) Clear() Trie[V] {
+	*o.root = *newTrieNode[V]()
+	*o.size = 0
+	return o
+}
+func (o Trie[V]) Clone() Trie[V] {
+	size := *o.size
+	return Trie[V]{root: o.root.deepCopy(), size: &size}
+}
+func (o Trie[V]) ForEach(f func(k Str, v V)) Trie[V] {
+	for k, v := range o.All() {
+		f(k, v)
+	}
+	return o
+}
+func (o Trie[V]) ToMap() map[Str]V {
+	res := make(map[Str]V, o.Len())
+	for k, v := range o.All() {
+		res[k] = v
+	}
+	return res
+}
+
+// 按字典序遍历所有键值对
+func (o Trie[V]) All() iter.Seq2[Str, V] { return o.WithPrefix("") }
+func (o Trie[V]) Keys() iter.Seq[Str]    { return seqKeys(o.All()) }
+func (o Trie[V]) Values() iter.Seq[V]    { return seqValues(o.All()) }
+func (o Trie[V]) KeySlice() []Str        { return slices.Collect(o.Keys()) }
+func (o Trie[V]) ValueSlice() []V        { return slices.Collect(o.Values()) }
+
+// --------------------基本操作--------------------
+func (o Trie[V]) Has(k Str) bool {
+	node := o.find(k)
+	return node != nil && node.has
+}
+
+// 键不存在时返回零值
+func (o Trie[V]) Get(k Str) V { v, _ := o.Lookup(k); return v }
+func (o Trie[V]) GetOr(k Str, v V) V {
+	if res, ok := o.Lookup(k); ok {
+		return res
+	}
+	return v
+}
+func (o Trie[V]) Lookup(k Str) (V, bool) {
+	if node := o.find(k); node != nil && node.has {
+		return node.val, true
+	}
+	return *new(V), false
+}
+
+// 设置键值对，已存在的键保留原来的权重
+func (o Trie[V]) Set(k Str, v V) Trie[V] {
+	path := []*trieNode[V]{o.root}
+	node := o.root
+	for _, r := range k.S() {
+		i, ok := node.search(r)
+		if !ok {
+			node.children = slices.Insert(node.children, i, trieEdge[V]{r, newTrieNode[V]()})
+		}
+		node = node.children[i].node
+		path = append(path, node)
+	}
+	if !node.has {
+		node.has = true
+		*o.size++
+		for _, p := range path {
+			p.count++
+		}
+		refreshMaxWeight(path)
+	}
+	node.val = v
+	return o
+}
+
+// 删除键，并移除不再包含任何键的节点
+func (o Trie[V]) Del(k Str) Trie[V] {
+	path := []*trieNode[V]{o.root}
+	runes := []rune(k.S())
+	for _, r := range runes {
+		node := path[len(path)-1].child(r)
+		if node == nil {
+			return o
+		}
+		path = append(path, node)
+	}
+	node := path[len(path)-1]
+	if !node.has {
+		return o
+	}
+	*node = trieNode[V]{children: node.children, count: node.count, maxWeight: node.maxWeight}
+	*o.size--
+	for i, p := range path {
+		if p.count--; p.count == 0 && i > 0 { // 该节点之后的整棵子树都已为空
+			parent := path[i-1]
+			j, _ := parent.search(runes[i-1])
+			parent.children = slices.Delete(parent.children, j, j+1)
+			path = path[:i]
+			break
+		}
+	}
+	refreshMaxWeight(path)
+	return o
+}
+
+// --------------------前缀操作--------------------
+
+// 是否存在以prefix开头的键
+func (o Trie[V]) HasPrefix(prefix Str) bool { return o.CountPrefix(prefix) > 0 }
+
+// 以prefix开头的键的个数，O(len(prefix))
+func (o Trie[V]) CountPrefix(prefix Str) int {
+	if node := o.find(prefix); node != nil {
+		return node.count
+	}
+	return 0
+}
+
+// 按字典序遍历以prefix开头的键值对
+func (o Trie[V]) WithPrefix(prefix Str) iter.Seq2[Str, V] {
+	return func(yield func(Str, V) bool) {
+		for k, node := range o.walk(prefix) {
+			if !yield(k, node.val) {
+				return
+			}
+		}
+	}
+}
+
+// 按字典序排列的以prefix开头的所有键
+func (o Trie[V]) KeysWithPrefix(prefix Str) Array[Str] {
+	return NewArrayFromSlice(slices.Collect(seqKeys(o.WithPrefix(prefix))))
+}
+
+// s的最长的、作为键存在的前缀，不存在时返回false
+func (o Trie[V]) LongestPrefixOf(s Str) (Str, bool) {
+	node, end, found := o.root, 0, o.root.has
+	str := s.S()
+	for i := 0; i < len(str); {
+		r, width := utf8.DecodeRuneInString(str[i:])
+		if node = node.child(r); node == nil {
+			break
+		}
+		if i += width; node.has {
+			end, found = i, true
+		}
+	}
+	return s[:end], found
+}
+
+// --------------------权重和自动补全--------------------
+
+// 设置键的权重，键不存在时会panic
+func (o Trie[V]) SetWeight(k Str, weight float64) Trie[V] {
+	path := o.path(k)
+	if path == nil || !path[len(path)-1].has {
+		panic("键不存在：" + k.S())
+	}
+	path[len(path)-1].weight = weight
+	refreshMaxWeight(path)
+	return o
+}
+
+// 键的权重，键不存在时返回0
+func (o Trie[V]) Weight(k Str) float64 {
+	if node := o.find(k); node != nil && node.has {
+		return node.weight
+	}
+	return 0
+}
+
+// 以prefix开头的权重最大的k个键，按权重从大到小排列，权重相同时按字典序
+// 按子树的最大权重从大到小展开节点，只访问可能进入前k个的子树，不需要遍历整个前缀下的所有键
+func (o Trie[V]) TopK(prefix Str, k int) Array[Str] {
+	type candidate struct {
+		key    Str
+		weight float64      // 键的权重，或者子树的最大权重
+		node   *trieNode[V] // 不为nil时表示尚未展开的子树，key为它的前缀
+	}
+	// 堆顶为权重最大的候选，权重相同时前缀较小的子树先展开，保证结果按字典序
+	h := NewHeap[candidate]().WithLess(func(a, b candidate) bool {
+		return a.weight > b.weight || a.weight == b.weight && a.key < b.key
+	})
+	if node := o.find(prefix); node != nil && k > 0 {
+		h.Push(candidate{prefix, node.maxWeight, node})
+	}
+	res := NewArray[Str]()
+	for h.Len() > 0 && res.Len() < k {
+		c := h.Pop()
+		if c.node == nil {
+			res.Push(c.key)
+			continue
+		}
+		if c.node.has {
+			h.Push(candidate{c.key, c.node.weight, nil})
+		}
+		for _, e := range c.node.children {
+			h.Push(candidate{c.key + Str(string(e.r)), e.node.maxWeight, e.node})
+		}
+	}
+	return res
+}
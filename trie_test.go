@@ -0,0 +1,138 @@
+package gods
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func Test字典树(t *testing.T) {
+	trie := NewTrie[int]()
+	for i, k := range []Str{"apple", "app", "application", "apt", "banana", "中文", "中国", "中国人"} {
+		trie.Set(k, i)
+	}
+	fmt.Println(trie)
+	if trie.Len() != 8 || trie.Get("app") != 1 || trie.Has("ap") || trie.Get("ap") != 0 {
+		t.Errorf("Set或Get错误")
+	}
+	keys := trie.KeysWithPrefix("app")
+	fmt.Println("以app开头的键：", keys)
+	if !slices.Equal(keys.ToSlice(), []Str{"app", "apple", "application"}) {
+		t.Errorf("KeysWithPrefix错误")
+	}
+	if trie.CountPrefix("ap") != 4 || trie.CountPrefix("中国") != 2 || trie.CountPrefix("x") != 0 || !trie.HasPrefix("中") {
+		t.Errorf("CountPrefix错误")
+	}
+	for s, want := range map[Str]Str{"applesauce": "apple", "apps": "app", "中国人民": "中国人", "中": "", "xyz": ""} {
+		got, ok := trie.LongestPrefixOf(s)
+		if got != want || ok != (want != "") {
+			t.Errorf("LongestPrefixOf(%v)=%v，期望%v", s, got, want)
+		}
+	}
+
+	clone := trie.Clone()
+	trie.Del("app").Del("apple").Del("missing").Del("ap")
+	if trie.Len() != 6 || trie.Has("app") || !trie.Has("application") || trie.CountPrefix("app") != 1 {
+		t.Errorf("Del错误")
+	}
+	trie.Del("application")
+	if trie.HasPrefix("app") || trie.root.child('a').child('p').child('p') != nil {
+		t.Errorf("Del后应移除空节点")
+	}
+	if clone.Len() != 8 || !clone.Has("apple") {
+		t.Errorf("Clone错误")
+	}
+	trie.Clear()
+	if trie.Len() != 0 || trie.HasPrefix("") {
+		t.Errorf("Clear错误")
+	}
+
+	// 与暴力结果比较
+	m := map[Str]int{}
+	for range 2000 {
+		var sb strings.Builder
+		for range rand.Intn(4) {
+			sb.WriteByte("abc"[rand.Intn(3)])
+		}
+		k := Str(sb.String())
+		if rand.Intn(3) == 0 {
+			trie.Del(k)
+			delete(m, k)
+		} else {
+			v := rand.Int()
+			trie.Set(k, v)
+			m[k] = v
+		}
+		cnt := 0
+		for key := range m {
+			if key.StartsWith(k) {
+				cnt++
+			}
+		}
+		if trie.CountPrefix(k) != cnt || trie.Len() != len(m) || trie.Get(k) != m[k] {
+			t.Fatalf("CountPrefix(%v)错误", k)
+		}
+	}
+	want := slices.Sorted(func(yield func(Str) bool) {
+		for k := range m {
+			yield(k)
+		}
+	})
+	if !slices.Equal(slices.Collect(trie.Keys()), want) {
+		t.Errorf("遍历顺序错误")
+	}
+}
+
+func Test字典树自动补全(t *testing.T) {
+	trie := NewTrie[struct{}]()
+	for k, w := range map[Str]float64{"go": 5, "golang": 9, "gopher": 9, "google": 7, "gone": 1, "java": 10} {
+		trie.Set(k, struct{}{}).SetWeight(k, w)
+	}
+	top := trie.TopK("go", 3)
+	fmt.Println("自动补全：", top)
+	if !slices.Equal(top.ToSlice(), []Str{"golang", "gopher", "google"}) {
+		t.Errorf("TopK错误")
+	}
+	if trie.TopK("go", 10).Len() != 5 || trie.TopK("x", 3).Len() != 0 || trie.TopK("go", 0).Len() != 0 {
+		t.Errorf("TopK边界错误")
+	}
+	if trie.Weight("java") != 10 || trie.Weight("jav") != 0 {
+		t.Errorf("Weight错误")
+	}
+
+	// 随机修改后与暴力排序的结果比较
+	trie.Clear()
+	weights := map[Str]float64{}
+	for range 2000 {
+		k := Str(fmt.Sprint(rand.Intn(300)))
+		switch rand.Intn(3) {
+		case 0:
+			trie.Del(k)
+			delete(weights, k)
+		default:
+			w := float64(rand.Intn(20))
+			trie.Set(k, struct{}{}).SetWeight(k, w)
+			weights[k] = w
+		}
+		prefix := Str(fmt.Sprint(rand.Intn(10)))
+		var want []Str
+		for k := range weights {
+			if k.StartsWith(prefix) {
+				want = append(want, k)
+			}
+		}
+		slices.SortFunc(want, func(a, b Str) int {
+			if weights[a] != weights[b] {
+				return cmp.Compare(weights[b], weights[a])
+			}
+			return a.Cmp(b)
+		})
+		want = want[:min(len(want), 5)]
+		if got := trie.TopK(prefix, 5).ToSlice(); !slices.Equal(got, want) {
+			t.Fatalf("TopK(%q)=%v，应为%v", prefix, got, want)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package gods
+
+import (
+	"fmt"
+	"maps"
+)
+
+// ===================================并查集===================================
+
+// 任意可比较元素的并查集，使用路径压缩和按大小合并
+// 元素在第一次调用Add或Union时加入，未加入的元素视为只包含自身的集合
+type UnionFind[T comparable] struct {
+	ids   map[T]int // 元素到编号的映射
+	elems *[]T      // 编号到元素的映射
+	dense DenseUnionFind
+}
+
+func NewUnionFind[T comparable]() UnionFind[T] {
+	return UnionFind[T]{ids: map[T]int{}, elems: new([]T), dense: NewDenseUnionFind(0)}
+}
+
+// --------------------Container接口--------------------
+func (o UnionFind[T]) Len() int       { return len(o.ids) }
+func (o UnionFind[T]) String() string { return "UnionFind" + fmt.Sprint(o.Groups().ValueSlice()) }
+func (o UnionFind[T]) Clear() UnionFind[T] {
+	clear(o.ids)
+	*o.elems = nil
+	*o.dense.parent = nil
+	*o.dense.count = 0
+	return o
+}
+func (o UnionFind[T]) Clone() UnionFind[T] {
+	elems := append([]T{}, *o.elems...)
+	return UnionFind[T]{ids: maps.Clone(o.ids), elems: &elems, dense: o.dense.Clone()}
+}
+
+// --------------------基本操作--------------------
+
+// 加入元素，已存在时不做任何操作
+func (o UnionFind[T]) Add(x T) UnionFind[T] { o.id(x); return o }
+func (o UnionFind[T]) Has(x T) bool         { _, ok := o.ids[x]; return ok }
+
+// 元素的编号，不存在时加入
+func (o UnionFind[T]) id(x T) int {
+	i, ok := o.ids[x]
+	if !ok {
+		i = o.dense.Add()
+		o.ids[x] = i
+		*o.elems = append(*o.elems, x)
+	}
+	return i
+}
+
+// 元素所在集合的代表元素，未加入的元素返回自身
+func (o UnionFind[T]) Find(x T) T {
+	i, ok := o.ids[x]
+	if !ok {
+		return x
+	}
+	return (*o.elems)[o.dense.Find(i)]
+}
+
+// 合并两个元素所在的集合，会加入不存在的元素，已在同一集合时返回false
+func (o UnionFind[T]) Union(x, y T) bool { return o.dense.Union(o.id(x), o.id(y)) }
+func (o UnionFind[T]) Connected(x, y T) bool {
+	if x == y {
+		return true
+	}
+	i, ok1 := o.ids[x]
+	j, ok2 := o.ids[y]
+	return ok1 && ok2 && o.dense.Connected(i, j)
+}
+
+// 元素所在集合的大小，未加入的元素返回1
+func (o UnionFind[T]) SizeOf(x T) int {
+	i, ok := o.ids[x]
+	if !ok {
+		return 1
+	}
+	return o.dense.SizeOf(i)
+}
+
+// 集合的个数
+func (o UnionFind[T]) Count() int { return o.dense.Count() }
+
+// 所有集合，键为代表元素，值为集合中的元素，按加入顺序排列
+func (o UnionFind[T]) Groups() HashMap[T, Array[T]] {
+	return groupsOf(len(*o.elems), o.dense.Find, func(i int) T { return (*o.elems)[i] })
+}
+
+// 按代表元素分组，f将编号转换为元素
+func groupsOf[T comparable](n int, find func(int) int, f func(int) T) HashMap[T, Array[T]] {
+	res := NewHashMap[T, Array[T]]().WithFactory(NewArray[T])
+	for i := range n {
+		res.Get(f(find(i))).Push(f(i))
+	}
+	return res.WithFactory(nil)
+}
+
+// ===================================稠密并查集===================================
+
+// 元素为0到n-1的并查集，使用切片代替哈希表，使用路径压缩和按大小合并
+type DenseUnionFind struct {
+	parent *[]int // 根节点保存集合大小的相反数
+	count  *int
+}
+
+func NewDenseUnionFind(n int) DenseUnionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = -1
+	}
+	return DenseUnionFind{parent: &parent, count: &n}
+}
+
+// --------------------Container接口--------------------
+func (o DenseUnionFind) Len() int { return len(*o.parent) }
+func (o DenseUnionFind) String() string {
+	return "DenseUnionFind" + fmt.Sprint(o.Groups().ValueSlice())
+}
+func (o DenseUnionFind) Clear() DenseUnionFind { // 所有元素恢复为单独的集合
+	for i := range *o.parent {
+		(*o.parent)[i] = -1
+	}
+	*o.count = o.Len()
+	return o
+}
+func (o DenseUnionFind) Clone() DenseUnionFind {
+	parent, count := append([]int{}, *o.parent...), *o.count
+	return DenseUnionFind{parent: &parent, count: &count}
+}
+
+// --------------------基本操作--------------------
+
+// 加入新元素，返回它的编号
+func (o DenseUnionFind) Add() int {
+	*o.parent = append(*o.parent, -1)
+	*o.count++
+	return o.Len() - 1
+}
+
+func (o DenseUnionFind) Find(x int) int {
+	parent := *o.parent
+	root := x
+	for parent[root] >= 0 {
+		root = parent[root]
+	}
+	for parent[x] >= 0 { // 路径压缩
+		parent[x], x = root, parent[x]
+	}
+	return root
+}
+
+// 合并两个元素所在的集合，已在同一集合时返回false
+func (o DenseUnionFind) Union(x, y int) bool {
+	x, y = o.Find(x), o.Find(y)
+	if x == y {
+		return false
+	}
+	parent := *o.parent
+	if parent[x] > parent[y] { // 小集合合并到大集合
+		x, y = y, x
+	}
+	parent[x] += parent[y]
+	parent[y] = x
+	*o.count--
+	return true
+}
+func (o DenseUnionFind) Connected(x, y int) bool { return o.Find(x) == o.Find(y) }
+func (o DenseUnionFind) SizeOf(x int) int        { return -(*o.parent)[o.Find(x)] }
+func (o DenseUnionFind) Count() int              { return *o.count }
+
+// 所有集合，键为代表元素，值为集合中的元素，按从小到大排列
+func (o DenseUnionFind) Groups() HashMap[int, Array[int]] {
+	return groupsOf(o.Len(), o.Find, func(i int) int { return i })
+}
+
+// ===================================可回滚并查集===================================
+
+// 元素为0到n-1的可撤销合并的并查集，只使用按大小合并，不使用路径压缩，操作的复杂度为O(logn)
+// 适用于离线算法，例如线段树分治和回滚莫队
+type RollbackUnionFind struct {
+	parent  *[]int // 根节点保存集合大小的相反数
+	count   *int
+	history *[]unionRecord // 每次成功合并的记录
+}
+
+// 将根节点child合并到根节点root下，size为child原来的集合大小的相反数
+type unionRecord struct{ root, child, size int }
+
+func NewRollbackUnionFind(n int) RollbackUnionFind {
+	d := NewDenseUnionFind(n)
+	return RollbackUnionFind{parent: d.parent, count: d.count, history: new([]unionRecord)}
+}
+
+// --------------------Container接口--------------------
+func (o RollbackUnionFind) Len() int { return len(*o.parent) }
+func (o RollbackUnionFind) String() string {
+	return "RollbackUnionFind" + fmt.Sprint(o.Groups().ValueSlice())
+}
+func (o RollbackUnionFind) Clear() RollbackUnionFind { // 所有元素恢复为单独的集合，并清空历史记录
+	DenseUnionFind{parent: o.parent, count: o.count}.Clear()
+	*o.history = nil
+	return o
+}
+func (o RollbackUnionFind) Clone() RollbackUnionFind {
+	d := DenseUnionFind{parent: o.parent, count: o.count}.Clone()
+	history := append([]unionRecord{}, *o.history...)
+	return RollbackUnionFind{parent: d.parent, count: d.count, history: &history}
+}
+
+// --------------------基本操作--------------------
+
+func (o RollbackUnionFind) Find(x int) int {
+	for (*o.parent)[x] >= 0 {
+		x = (*o.parent)[x]
+	}
+	return x
+}
+
+// 合并两个元素所在的集合，已在同一集合时返回false，且不会产生历史记录
+func (o RollbackUnionFind) Union(x, y int) bool {
+	x, y = o.Find(x), o.Find(y)
+	if x == y {
+		return false
+	}
+	parent := *o.parent
+	if parent[x] > parent[y] {
+		x, y = y, x
+	}
+	*o.history = append(*o.history, unionRecord{x, y, parent[y]})
+	parent[x] += parent[y]
+	parent[y] = x
+	*o.count--
+	return true
+}
+func (o RollbackUnionFind) Connected(x, y int) bool { return o.Find(x) == o.Find(y) }
+func (o RollbackUnionFind) SizeOf(x int) int        { return -(*o.parent)[o.Find(x)] }
+func (o RollbackUnionFind) Count() int              { return *o.count }
+
+// 所有集合，键为代表元素，值为集合中的元素，按从小到大排列
+func (o RollbackUnionFind) Groups() HashMap[int, Array[int]] {
+	return groupsOf(o.Len(), o.Find, func(i int) int { return i })
+}
+
+// --------------------回滚操作--------------------
+
+// 当前的版本号，等于成功合并的次数，用于Rollback
+func (o RollbackUnionFind) Snapshot() int { return len(*o.history) }
+
+// 撤销最近一次成功的合并，没有可撤销的合并时返回false
+func (o RollbackUnionFind) Undo() bool {
+	history := *o.history
+	if len(history) == 0 {
+		return false
+	}
+	r := history[len(history)-1]
+	*o.history = history[:len(history)-1]
+	parent := *o.parent
+	parent[r.child] = r.size
+	parent[r.root] -= r.size
+	*o.count++
+	return true
+}
+
+// 撤销合并，直到回到Snapshot返回的版本
+func (o RollbackUnionFind) Rollback(snapshot int) RollbackUnionFind {
+	if snapshot < 0 || snapshot > o.Snapshot() {
+		panic(fmt.Sprintf("版本号超出范围：%d", snapshot))
+	}
+	for o.Snapshot() > snapshot {
+		o.Undo()
+	}
+	return o
+}
@@ -0,0 +1,107 @@
+package gods
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func Test并查集(t *testing.T) {
+	uf := NewUnionFind[string]()
+	uf.Union("a", "b")
+	uf.Union("c", "d")
+	uf.Union("b", "d")
+	uf.Add("e")
+	fmt.Println(uf, "集合个数：", uf.Count())
+	if !uf.Connected("a", "c") || uf.Connected("a", "e") || uf.Connected("a", "x") || !uf.Connected("x", "x") {
+		t.Errorf("Connected错误")
+	}
+	if uf.Count() != 2 || uf.SizeOf("a") != 4 || uf.SizeOf("x") != 1 || uf.Find("x") != "x" || uf.Len() != 5 {
+		t.Errorf("统计信息错误")
+	}
+	if uf.Union("a", "d") {
+		t.Errorf("已在同一集合时Union应返回false")
+	}
+	groups := uf.Groups()
+	if groups.Len() != 2 || !slices.Equal(groups.Get(uf.Find("c")).ToSlice(), []string{"a", "b", "c", "d"}) {
+		t.Errorf("Groups错误：%v", groups)
+	}
+
+	clone := uf.Clone()
+	uf.Clear()
+	if uf.Count() != 0 || uf.Len() != 0 || clone.Count() != 2 {
+		t.Errorf("Clear或Clone错误")
+	}
+}
+
+func Test稠密并查集(t *testing.T) {
+	n := 100
+	uf := NewDenseUnionFind(n)
+	label := make([]int, n) // 暴力维护每个元素所在集合的编号
+	for i := range label {
+		label[i] = i
+	}
+	for range 300 {
+		x, y := rand.Intn(n), rand.Intn(n)
+		merged := uf.Union(x, y)
+		if merged != (label[x] != label[y]) {
+			t.Fatalf("Union(%d, %d)错误", x, y)
+		}
+		from, to := label[y], label[x]
+		for i := range label {
+			if label[i] == from {
+				label[i] = to
+			}
+		}
+		a, b := rand.Intn(n), rand.Intn(n)
+		if uf.Connected(a, b) != (label[a] == label[b]) {
+			t.Fatalf("Connected(%d, %d)错误", a, b)
+		}
+		size := 0
+		for _, l := range label {
+			if l == label[a] {
+				size++
+			}
+		}
+		if uf.SizeOf(a) != size {
+			t.Fatalf("SizeOf(%d)错误", a)
+		}
+	}
+	distinct := map[int]bool{}
+	for _, l := range label {
+		distinct[l] = true
+	}
+	if uf.Count() != len(distinct) || uf.Groups().Len() != len(distinct) {
+		t.Errorf("Count错误")
+	}
+	if i := uf.Add(); i != n || uf.Count() != len(distinct)+1 {
+		t.Errorf("Add错误")
+	}
+}
+
+func Test可回滚并查集(t *testing.T) {
+	uf := NewRollbackUnionFind(6)
+	uf.Union(0, 1)
+	s := uf.Snapshot()
+	uf.Union(2, 3)
+	uf.Union(1, 3)
+	uf.Union(0, 2) // 已在同一集合，不产生记录
+	fmt.Println(uf, "版本号：", uf.Snapshot())
+	if uf.Snapshot() != 3 || uf.SizeOf(2) != 4 || uf.Count() != 3 {
+		t.Errorf("合并错误")
+	}
+	uf.Undo()
+	if uf.Connected(0, 2) || uf.SizeOf(3) != 2 || uf.Count() != 4 {
+		t.Errorf("Undo错误")
+	}
+	uf.Union(4, 5)
+	uf.Rollback(s)
+	if uf.Connected(2, 3) || uf.Connected(4, 5) || !uf.Connected(0, 1) || uf.Count() != 5 {
+		t.Errorf("Rollback错误")
+	}
+	uf.Rollback(0)
+	if uf.Undo() || uf.Count() != 6 {
+		t.Errorf("回滚到初始状态错误")
+	}
+}
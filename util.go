@@ -1,7 +1,9 @@
 package gods
 
 import (
+	"cmp"
 	"fmt"
+	"iter"
 	"reflect"
 	"strings"
 )
@@ -37,6 +39,122 @@ func (o Optional[T]) Exists() bool {
 	return o.v != nil
 }
 
+// ===================================自然排序===================================
+
+// 使用反射获取整数、浮点数和字符串类型（包括自定义类型）的比较函数，其他类型返回nil
+func reflectCmp[T any]() func(T, T) int {
+	Val := reflect.ValueOf
+	v := Val(*new(T))
+
+	if v.CanInt() {
+		return func(a, b T) int {
+			return cmp.Compare[int64](Val(a).Int(), Val(b).Int())
+		}
+	} else if v.CanUint() {
+		return func(a, b T) int {
+			return cmp.Compare[uint64](Val(a).Uint(), Val(b).Uint())
+		}
+	} else if v.CanFloat() {
+		return func(a, b T) int {
+			return cmp.Compare[float64](Val(a).Float(), Val(b).Float())
+		}
+	} else if v.Kind() == reflect.String {
+		return func(a, b T) int {
+			return cmp.Compare[string](Val(a).String(), Val(b).String())
+		}
+	}
+	return nil
+}
+
+// 解码零值有序容器时使用的自然排序比较函数，常见类型直接使用cmp.Compare，只有自定义类型使用反射
+func naturalCmp[T any]() (func(T, T) int, error) {
+	var c any
+	switch any(*new(T)).(type) {
+	case int:
+		c = cmp.Compare[int]
+	case int8:
+		c = cmp.Compare[int8]
+	case int16:
+		c = cmp.Compare[int16]
+	case int32:
+		c = cmp.Compare[int32]
+	case int64:
+		c = cmp.Compare[int64]
+	case uint:
+		c = cmp.Compare[uint]
+	case uint8:
+		c = cmp.Compare[uint8]
+	case uint16:
+		c = cmp.Compare[uint16]
+	case uint32:
+		c = cmp.Compare[uint32]
+	case uint64:
+		c = cmp.Compare[uint64]
+	case uintptr:
+		c = cmp.Compare[uintptr]
+	case float32:
+		c = cmp.Compare[float32]
+	case float64:
+		c = cmp.Compare[float64]
+	case string:
+		c = cmp.Compare[string]
+	case Str:
+		c = cmp.Compare[Str]
+	}
+	if c, ok := c.(func(T, T) int); ok {
+		return c, nil
+	}
+	if c := reflectCmp[T](); c != nil {
+		return c, nil
+	}
+	return nil, errNoNaturalOrder[T]()
+}
+func errNoNaturalOrder[T any]() error {
+	return fmt.Errorf("gods: 类型%T没有自然排序，请先使用比较函数创建容器再解码", *new(T))
+}
+
+// 解码零值自定义哈希容器时返回的错误
+func errNoHasher[T any]() error {
+	return fmt.Errorf("gods: 类型%T的键需要Hasher，请先使用NewCustomHashMap或NewCustomHashSet创建容器再解码", *new(T))
+}
+
+// ===================================迭代器工具===================================
+
+// 只保留键值对迭代器中的键
+func seqKeys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// 只保留键值对迭代器中的值
+func seqValues[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// 将元素按重数展开
+func repeatKeys[T any](seq iter.Seq2[T, int]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for x, cnt := range seq {
+			for range cnt {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // ===================================树状打印工具===================================
 
 // 模拟二叉树节点的接口，左右孩子要求返回指针